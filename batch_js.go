@@ -0,0 +1,36 @@
+//go:build js && wasm
+
+package p5go
+
+import (
+	"encoding/binary"
+	"math"
+	"syscall/js"
+)
+
+// drawCircles draws the circles packed in a Float32Array without a call from Go per circle.
+var drawCircles = global.Get("Function").New("p", "data", "stride", `
+for (let i = 0; i + stride <= data.length; i += stride) {
+	p.fill(data[i + 3], data[i + 4], data[i + 5], data[i + 6]);
+	p.circle(data[i], data[i + 1], data[i + 2]);
+}`)
+
+// Circles draws many filled circles in a single call into JavaScript, which is much faster than
+// calling Fill and Circle for each one. data holds CircleBatchStride values per circle:
+// x, y, diameter, red, green, blue and alpha. The fill is left at the color of the last circle.
+func (c *Canvas) Circles(data []float32) {
+	if len(data) == 0 {
+		return
+	}
+	// the byte buffer is kept between frames to avoid garbage on every draw
+	if cap(c.batch) < len(data)*4 {
+		c.batch = make([]byte, len(data)*4)
+	}
+	buf := c.batch[:len(data)*4]
+	for i, v := range data {
+		binary.LittleEndian.PutUint32(buf[i*4:], math.Float32bits(v))
+	}
+	bytes := global.Get("Uint8Array").New(len(buf))
+	js.CopyBytesToJS(bytes, buf)
+	drawCircles.Invoke(c.p5Instance, global.Get("Float32Array").New(bytes.Get("buffer")), CircleBatchStride)
+}
@@ -0,0 +1,251 @@
+// Package contour traces iso lines of scalar fields with marching squares.
+package contour
+
+import (
+	"math"
+
+	"github.com/ryomak/p5go"
+)
+
+// Grid is a scalar field sampled on a regular grid. Sample (0, 0) is at the top-left corner of
+// Bounds and sample (Cols-1, Rows-1) at the bottom-right corner.
+type Grid struct {
+	Bounds     p5go.Rectangle
+	Cols, Rows int
+	Values     []float64 // row by row, Values[j*Cols+i] is sample (i, j)
+}
+
+// NewGrid creates a grid of zero values over bounds.
+func NewGrid(bounds p5go.Rectangle, cols, rows int) *Grid {
+	return &Grid{Bounds: bounds, Cols: cols, Rows: rows, Values: make([]float64, cols*rows)}
+}
+
+// SampleFunc creates a grid by evaluating f at every sample position, for example a noise lookup.
+func SampleFunc(bounds p5go.Rectangle, cols, rows int, f func(p p5go.Vector) float64) *Grid {
+	g := NewGrid(bounds, cols, rows)
+	for j := 0; j < rows; j++ {
+		for i := 0; i < cols; i++ {
+			g.Values[j*cols+i] = f(g.Point(i, j))
+		}
+	}
+	return g
+}
+
+// At returns sample (i, j).
+func (g *Grid) At(i, j int) float64 {
+	return g.Values[j*g.Cols+i]
+}
+
+// Set sets sample (i, j).
+func (g *Grid) Set(i, j int, v float64) {
+	g.Values[j*g.Cols+i] = v
+}
+
+// Point returns the position of sample (i, j).
+func (g *Grid) Point(i, j int) p5go.Vector {
+	return g.point(float64(i), float64(j))
+}
+
+// Range returns the smallest and largest values of the grid.
+func (g *Grid) Range() (lo, hi float64) {
+	lo, hi = math.Inf(1), math.Inf(-1)
+	for _, v := range g.Values {
+		lo, hi = math.Min(lo, v), math.Max(hi, v)
+	}
+	return lo, hi
+}
+
+// Levels returns n levels evenly spaced between lo and hi, excluding both ends.
+func Levels(lo, hi float64, n int) []float64 {
+	levels := make([]float64, n)
+	for i := range levels {
+		levels[i] = lo + (hi-lo)*float64(i+1)/float64(n+1)
+	}
+	return levels
+}
+
+// Contour is a connected iso line. Closed contours form a loop and do not repeat their first point;
+// open ones end at the border of the grid.
+type Contour struct {
+	Level  float64
+	Points []p5go.Vector
+	Closed bool
+}
+
+// Draw draws the contour as a single shape.
+func (c Contour) Draw(r p5go.Renderer) {
+	if c.Closed {
+		p5go.DrawPolygon(r, c.Points)
+		return
+	}
+	p5go.DrawPolyline(r, c.Points)
+}
+
+// Draw draws every contour.
+func Draw(r p5go.Renderer, contours []Contour) {
+	for _, c := range contours {
+		c.Draw(r)
+	}
+}
+
+// Isolines traces the contours of the grid at each level.
+func (g *Grid) Isolines(levels ...float64) []Contour {
+	var out []Contour
+	for _, l := range levels {
+		out = append(out, g.Contours(l)...)
+	}
+	return out
+}
+
+// cell edges
+const (
+	edgeTop = iota
+	edgeRight
+	edgeBottom
+	edgeLeft
+)
+
+// segments lists the pairs of crossed cell edges for each corner configuration, with the bits
+// top-left 8, top-right 4, bottom-right 2 and bottom-left 1 set for corners at or above the level.
+// The saddles 5 and 10 use the second entry when the center of the cell is below the level.
+var segments = [16][][2]int{
+	1:  {{edgeLeft, edgeBottom}},
+	2:  {{edgeBottom, edgeRight}},
+	3:  {{edgeLeft, edgeRight}},
+	4:  {{edgeTop, edgeRight}},
+	5:  {{edgeTop, edgeLeft}, {edgeBottom, edgeRight}},
+	6:  {{edgeTop, edgeBottom}},
+	7:  {{edgeTop, edgeLeft}},
+	8:  {{edgeTop, edgeLeft}},
+	9:  {{edgeTop, edgeBottom}},
+	10: {{edgeTop, edgeRight}, {edgeLeft, edgeBottom}},
+	11: {{edgeTop, edgeRight}},
+	12: {{edgeLeft, edgeRight}},
+	13: {{edgeBottom, edgeRight}},
+	14: {{edgeLeft, edgeBottom}},
+}
+
+// Contours traces the contours of the grid at level, with the crossing points interpolated linearly
+// along the cell edges. Segments are joined into polylines so each line is drawn in one stroke.
+func (g *Grid) Contours(level float64) []Contour {
+	if g.Cols < 2 || g.Rows < 2 {
+		return nil
+	}
+	// every crossing lies on a grid edge: horizontal edges come first, then vertical ones
+	hEdges := (g.Cols - 1) * g.Rows
+	edgeID := func(i, j, side int) int {
+		switch side {
+		case edgeTop:
+			return j*(g.Cols-1) + i
+		case edgeBottom:
+			return (j+1)*(g.Cols-1) + i
+		case edgeLeft:
+			return hEdges + j*g.Cols + i
+		default:
+			return hEdges + j*g.Cols + i + 1
+		}
+	}
+
+	links := map[int][]int{}
+	points := map[int]p5go.Vector{}
+	var order []int
+	connect := func(a, b int) {
+		for _, id := range [2]int{a, b} {
+			if _, ok := links[id]; !ok {
+				order = append(order, id)
+			}
+		}
+		links[a] = append(links[a], b)
+		links[b] = append(links[b], a)
+	}
+
+	for j := 0; j < g.Rows-1; j++ {
+		for i := 0; i < g.Cols-1; i++ {
+			tl, tr := g.At(i, j), g.At(i+1, j)
+			br, bl := g.At(i+1, j+1), g.At(i, j+1)
+			c := 0
+			for bit, v := range [4]float64{bl, br, tr, tl} {
+				if v >= level {
+					c |= 1 << bit
+				}
+			}
+			segs := segments[c]
+			if (c == 5 || c == 10) && (tl+tr+br+bl)/4 < level {
+				segs = segments[15-c]
+			}
+			for _, s := range segs {
+				a, b := edgeID(i, j, s[0]), edgeID(i, j, s[1])
+				points[a] = g.crossing(i, j, s[0], level)
+				points[b] = g.crossing(i, j, s[1], level)
+				connect(a, b)
+			}
+		}
+	}
+
+	var out []Contour
+	visited := map[int]bool{}
+	walk := func(start int) Contour {
+		c := Contour{Level: level}
+		prev, cur := -1, start
+		for {
+			visited[cur] = true
+			c.Points = append(c.Points, points[cur])
+			next := -1
+			for _, n := range links[cur] {
+				if n != prev && !visited[n] {
+					next = n
+					break
+				}
+			}
+			if next < 0 {
+				for _, n := range links[cur] {
+					if n == start && n != prev {
+						c.Closed = true
+					}
+				}
+				return c
+			}
+			prev, cur = cur, next
+		}
+	}
+	// open contours start at the border, where a crossing has a single neighbour
+	for _, id := range order {
+		if !visited[id] && len(links[id]) == 1 {
+			out = append(out, walk(id))
+		}
+	}
+	for _, id := range order {
+		if !visited[id] {
+			out = append(out, walk(id))
+		}
+	}
+	return out
+}
+
+// crossing returns the point where the level crosses the given edge of cell (i, j).
+func (g *Grid) crossing(i, j, side int, level float64) p5go.Vector {
+	var i0, j0, i1, j1 int
+	switch side {
+	case edgeTop:
+		i0, j0, i1, j1 = i, j, i+1, j
+	case edgeRight:
+		i0, j0, i1, j1 = i+1, j, i+1, j+1
+	case edgeBottom:
+		i0, j0, i1, j1 = i, j+1, i+1, j+1
+	default:
+		i0, j0, i1, j1 = i, j, i, j+1
+	}
+	a, b := g.At(i0, j0), g.At(i1, j1)
+	t := 0.5
+	if a != b {
+		t = (level - a) / (b - a)
+	}
+	return g.point(float64(i0)+t*float64(i1-i0), float64(j0)+t*float64(j1-j0))
+}
+
+func (g *Grid) point(i, j float64) p5go.Vector {
+	return p5go.Vector{
+		X: g.Bounds.Position.X + g.Bounds.Size.X*i/float64(max(1, g.Cols-1)),
+		Y: g.Bounds.Position.Y + g.Bounds.Size.Y*j/float64(max(1, g.Rows-1)),
+	}
+}
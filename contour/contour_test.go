@@ -0,0 +1,162 @@
+package contour
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ryomak/p5go"
+)
+
+var bounds = p5go.Rectangle{Size: p5go.Vector{X: 100, Y: 100}}
+
+// square2x2 creates a single cell of side 10 with the given corner values.
+func square2x2(tl, tr, br, bl float64) *Grid {
+	g := NewGrid(p5go.Rectangle{Size: p5go.Vector{X: 10, Y: 10}}, 2, 2)
+	g.Set(0, 0, tl)
+	g.Set(1, 0, tr)
+	g.Set(1, 1, br)
+	g.Set(0, 1, bl)
+	return g
+}
+
+func distanceTo(center p5go.Vector) func(p p5go.Vector) float64 {
+	return func(p p5go.Vector) float64 { return p.Dist(center) }
+}
+
+func TestContours(t *testing.T) {
+	type segment [2]p5go.Vector
+	tests := []struct {
+		name  string
+		grid  *Grid
+		level float64
+		// closed holds whether each contour is closed
+		closed []bool
+		// segments are the expected two point contours, in any order and direction
+		segments []segment
+		// check is called with every contour
+		check func(t *testing.T, c Contour)
+	}{
+		{
+			name:   "radial field",
+			grid:   SampleFunc(bounds, 21, 21, distanceTo(p5go.Vector{X: 50, Y: 50})),
+			level:  25,
+			closed: []bool{true},
+			check: func(t *testing.T, c Contour) {
+				for _, p := range c.Points {
+					if d := p.Dist(p5go.Vector{X: 50, Y: 50}); math.Abs(d-25) > 1 {
+						t.Errorf("point %v at distance %v from the center, want 25", p, d)
+					}
+				}
+			},
+		},
+		{
+			name:   "field cut by the border",
+			grid:   SampleFunc(bounds, 21, 21, distanceTo(p5go.Vector{})),
+			level:  50,
+			closed: []bool{false},
+			check: func(t *testing.T, c Contour) {
+				first, last := c.Points[0], c.Points[len(c.Points)-1]
+				if !(first.Y == 0 && last.X == 0 || first.X == 0 && last.Y == 0) {
+					t.Errorf("open contour runs from %v to %v, want it to end on the top and left edges", first, last)
+				}
+				for _, p := range c.Points {
+					if d := p.Dist(p5go.Vector{}); math.Abs(d-50) > 1 {
+						t.Errorf("point %v at distance %v from the corner, want 50", p, d)
+					}
+				}
+			},
+		},
+		{
+			name:   "two open lines",
+			grid:   SampleFunc(bounds, 11, 11, func(p p5go.Vector) float64 { return math.Abs(p.X - 50) }),
+			level:  25,
+			closed: []bool{false, false},
+			check: func(t *testing.T, c Contour) {
+				first, last := c.Points[0], c.Points[len(c.Points)-1]
+				if math.Min(first.Y, last.Y) != 0 || math.Max(first.Y, last.Y) != 100 {
+					t.Errorf("open contour runs from %v to %v, want it to cross the grid", first, last)
+				}
+				for _, p := range c.Points {
+					if p.X != 25 && p.X != 75 {
+						t.Errorf("point %v off the lines x = 25 and x = 75", p)
+					}
+				}
+			},
+		},
+		{
+			name:     "saddle 5, center above",
+			grid:     square2x2(0, 1, 0, 1),
+			level:    0.4,
+			closed:   []bool{false, false},
+			segments: []segment{{{X: 4, Y: 0}, {X: 0, Y: 4}}, {{X: 6, Y: 10}, {X: 10, Y: 6}}},
+		},
+		{
+			name:     "saddle 5, center below",
+			grid:     square2x2(0, 1, 0, 1),
+			level:    0.6,
+			closed:   []bool{false, false},
+			segments: []segment{{{X: 6, Y: 0}, {X: 10, Y: 4}}, {{X: 0, Y: 6}, {X: 4, Y: 10}}},
+		},
+		{
+			name:     "saddle 10, center above",
+			grid:     square2x2(1, 0, 1, 0),
+			level:    0.4,
+			closed:   []bool{false, false},
+			segments: []segment{{{X: 6, Y: 0}, {X: 10, Y: 4}}, {{X: 0, Y: 6}, {X: 4, Y: 10}}},
+		},
+		{
+			name:     "saddle 10, center below",
+			grid:     square2x2(1, 0, 1, 0),
+			level:    0.6,
+			closed:   []bool{false, false},
+			segments: []segment{{{X: 4, Y: 0}, {X: 0, Y: 4}}, {{X: 6, Y: 10}, {X: 10, Y: 6}}},
+		},
+		{
+			name:  "single column",
+			grid:  SampleFunc(bounds, 1, 10, distanceTo(p5go.Vector{})),
+			level: 50,
+		},
+		{
+			name:  "single row",
+			grid:  SampleFunc(bounds, 10, 1, distanceTo(p5go.Vector{})),
+			level: 50,
+		},
+		{
+			name:  "level out of range",
+			grid:  SampleFunc(bounds, 10, 10, distanceTo(p5go.Vector{})),
+			level: 500,
+		},
+	}
+	near := func(a, b p5go.Vector) bool { return a.Dist(b) < 1e-9 }
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.grid.Contours(tt.level)
+			if len(got) != len(tt.closed) {
+				t.Fatalf("%d contours, want %d", len(got), len(tt.closed))
+			}
+			for i, c := range got {
+				if c.Closed != tt.closed[i] {
+					t.Errorf("contour %d: Closed = %v, want %v", i, c.Closed, tt.closed[i])
+				}
+				if c.Level != tt.level {
+					t.Errorf("contour %d: Level = %v, want %v", i, c.Level, tt.level)
+				}
+				if tt.check != nil {
+					tt.check(t, c)
+				}
+			}
+			for _, s := range tt.segments {
+				found := false
+				for _, c := range got {
+					if len(c.Points) == 2 &&
+						(near(c.Points[0], s[0]) && near(c.Points[1], s[1]) || near(c.Points[0], s[1]) && near(c.Points[1], s[0])) {
+						found = true
+					}
+				}
+				if !found {
+					t.Errorf("no contour from %v to %v in %v", s[0], s[1], got)
+				}
+			}
+		})
+	}
+}
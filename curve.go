@@ -0,0 +1,233 @@
+package p5go
+
+import (
+	"math"
+	"sort"
+)
+
+// BezierPoint evaluates one coordinate of a cubic Bezier curve at t, where a and d are the
+// anchor points and b and c the control points, like p5.js bezierPoint.
+func BezierPoint(a, b, c, d, t float64) float64 {
+	u := 1 - t
+	return u*u*u*a + 3*u*u*t*b + 3*u*t*t*c + t*t*t*d
+}
+
+// BezierTangent evaluates the derivative of one coordinate of a cubic Bezier curve at t, like p5.js bezierTangent.
+func BezierTangent(a, b, c, d, t float64) float64 {
+	u := 1 - t
+	return 3*u*u*(b-a) + 6*u*t*(c-b) + 3*t*t*(d-c)
+}
+
+// CurvePoint evaluates one coordinate of a Catmull-Rom curve segment at t, where b and c are the
+// points the segment goes through and a and d the neighbouring control points, like p5.js curvePoint.
+func CurvePoint(a, b, c, d, t float64) float64 {
+	return CardinalPoint(a, b, c, d, t, 0)
+}
+
+// CurveTangent evaluates the derivative of one coordinate of a Catmull-Rom curve segment at t, like p5.js curveTangent.
+func CurveTangent(a, b, c, d, t float64) float64 {
+	return CardinalTangent(a, b, c, d, t, 0)
+}
+
+// CardinalPoint is CurvePoint for a curve with the given tightness, as set by p5.js curveTightness.
+// A tightness of 0 is a Catmull-Rom curve and 1 connects the points with straight lines.
+func CardinalPoint(a, b, c, d, t, tightness float64) float64 {
+	s := (1 - tightness) / 2
+	t2, t3 := t*t, t*t*t
+	h00 := 2*t3 - 3*t2 + 1
+	h10 := t3 - 2*t2 + t
+	h01 := -2*t3 + 3*t2
+	h11 := t3 - t2
+	return h00*b + h10*s*(c-a) + h01*c + h11*s*(d-b)
+}
+
+// CardinalTangent is CurveTangent for a curve with the given tightness.
+func CardinalTangent(a, b, c, d, t, tightness float64) float64 {
+	s := (1 - tightness) / 2
+	t2 := t * t
+	h00 := 6*t2 - 6*t
+	h10 := 3*t2 - 4*t + 1
+	h01 := -6*t2 + 6*t
+	h11 := 3*t2 - 2*t
+	return h00*b + h10*s*(c-a) + h01*c + h11*s*(d-b)
+}
+
+// Path is a parametric curve defined for t between 0 and 1.
+type Path interface {
+	Point(t float64) Vector
+	Tangent(t float64) Vector
+}
+
+// CubicBezier is a cubic Bezier curve from P0 to P3 with the control points P1 and P2.
+type CubicBezier struct {
+	P0, P1, P2, P3 Vector
+}
+
+// Point returns the point of the curve at t.
+func (b CubicBezier) Point(t float64) Vector {
+	return Vector{
+		X: BezierPoint(b.P0.X, b.P1.X, b.P2.X, b.P3.X, t),
+		Y: BezierPoint(b.P0.Y, b.P1.Y, b.P2.Y, b.P3.Y, t),
+	}
+}
+
+// Tangent returns the derivative of the curve at t.
+func (b CubicBezier) Tangent(t float64) Vector {
+	return Vector{
+		X: BezierTangent(b.P0.X, b.P1.X, b.P2.X, b.P3.X, t),
+		Y: BezierTangent(b.P0.Y, b.P1.Y, b.P2.Y, b.P3.Y, t),
+	}
+}
+
+// Spline is a Catmull-Rom spline going through every point.
+// Tightness works like p5.js curveTightness. A closed spline also connects the last point to the first.
+type Spline struct {
+	Points    []Vector
+	Tightness float64
+	Closed    bool
+}
+
+// Segments returns the number of curve segments of the spline.
+func (s Spline) Segments() int {
+	n := len(s.Points)
+	if n < 2 {
+		return 0
+	}
+	if s.Closed {
+		return n
+	}
+	return n - 1
+}
+
+// Point returns the point of the spline at t, where each segment covers an equal range of t.
+func (s Spline) Point(t float64) Vector {
+	if len(s.Points) == 1 {
+		return s.Points[0]
+	}
+	a, b, c, d, u := s.segment(t)
+	return Vector{
+		X: CardinalPoint(a.X, b.X, c.X, d.X, u, s.Tightness),
+		Y: CardinalPoint(a.Y, b.Y, c.Y, d.Y, u, s.Tightness),
+	}
+}
+
+// Tangent returns the derivative of the spline at t with respect to t.
+func (s Spline) Tangent(t float64) Vector {
+	if len(s.Points) < 2 {
+		return Vector{}
+	}
+	a, b, c, d, u := s.segment(t)
+	n := float64(s.Segments())
+	return Vector{
+		X: CardinalTangent(a.X, b.X, c.X, d.X, u, s.Tightness) * n,
+		Y: CardinalTangent(a.Y, b.Y, c.Y, d.Y, u, s.Tightness) * n,
+	}
+}
+
+// segment returns the four control points of the segment containing t and the local parameter.
+func (s Spline) segment(t float64) (a, b, c, d Vector, u float64) {
+	n := s.Segments()
+	t = math.Max(0, math.Min(1, t)) * float64(n)
+	i := int(t)
+	if i >= n {
+		i = n - 1
+	}
+	u = t - float64(i)
+	return s.at(i - 1), s.at(i), s.at(i + 1), s.at(i + 2), u
+}
+
+// at returns the i-th point, wrapping for closed splines and repeating the end points for open ones.
+func (s Spline) at(i int) Vector {
+	n := len(s.Points)
+	if s.Closed {
+		return s.Points[((i%n)+n)%n]
+	}
+	return s.Points[max(0, min(n-1, i))]
+}
+
+// ArcLength maps distances along a Path to curve parameters, for moving at constant speed
+// or spacing points evenly.
+type ArcLength struct {
+	path    Path
+	lengths []float64 // cumulative length at t = i / (len(lengths) - 1)
+}
+
+// NewArcLength measures the path by sampling it at the given number of segments.
+func NewArcLength(p Path, samples int) *ArcLength {
+	if samples < 1 {
+		samples = 1
+	}
+	lengths := make([]float64, samples+1)
+	prev := p.Point(0)
+	for i := 1; i <= samples; i++ {
+		cur := p.Point(float64(i) / float64(samples))
+		lengths[i] = lengths[i-1] + math.Hypot(cur.X-prev.X, cur.Y-prev.Y)
+		prev = cur
+	}
+	return &ArcLength{path: p, lengths: lengths}
+}
+
+// Length returns the total length of the path.
+func (a *ArcLength) Length() float64 {
+	return a.lengths[len(a.lengths)-1]
+}
+
+// T returns the curve parameter at the given distance from the start.
+func (a *ArcLength) T(distance float64) float64 {
+	total := a.Length()
+	if distance <= 0 || total == 0 {
+		return 0
+	}
+	if distance >= total {
+		return 1
+	}
+	i := sort.SearchFloat64s(a.lengths, distance)
+	l0, l1 := a.lengths[i-1], a.lengths[i]
+	f := 0.0
+	if l1 > l0 {
+		f = (distance - l0) / (l1 - l0)
+	}
+	return (float64(i-1) + f) / float64(len(a.lengths)-1)
+}
+
+// PointAt returns the point at the given distance from the start.
+func (a *ArcLength) PointAt(distance float64) Vector {
+	return a.path.Point(a.T(distance))
+}
+
+// EvenPoints returns n points evenly spaced along the path, including both ends.
+func (a *ArcLength) EvenPoints(n int) []Vector {
+	if n < 2 {
+		return []Vector{a.path.Point(0)}
+	}
+	pts := make([]Vector, n)
+	step := a.Length() / float64(n-1)
+	for i := range pts {
+		pts[i] = a.PointAt(step * float64(i))
+	}
+	return pts
+}
+
+// Spaced returns points along the path separated by the given distance, starting at the start.
+func (a *ArcLength) Spaced(spacing float64) []Vector {
+	if spacing <= 0 {
+		return nil
+	}
+	var pts []Vector
+	for d := 0.0; d <= a.Length(); d += spacing {
+		pts = append(pts, a.PointAt(d))
+	}
+	return pts
+}
+
+// SamplePath returns segments+1 points of the path at evenly spaced parameters.
+func SamplePath(p Path, segments int) []Vector {
+	if segments < 1 {
+		segments = 1
+	}
+	pts := make([]Vector, segments+1)
+	for i := range pts {
+		pts[i] = p.Point(float64(i) / float64(segments))
+	}
+	return pts
+}
@@ -0,0 +1,51 @@
+//go:build js && wasm
+
+package p5go
+
+// BezierPoint evaluates one coordinate of a cubic Bezier curve at t.
+func (c *Canvas) BezierPoint(a, b, c1, d, t float64) float64 {
+	return BezierPoint(a, b, c1, d, t)
+}
+
+// BezierTangent evaluates the derivative of one coordinate of a cubic Bezier curve at t.
+func (c *Canvas) BezierTangent(a, b, c1, d, t float64) float64 {
+	return BezierTangent(a, b, c1, d, t)
+}
+
+// CurvePoint evaluates one coordinate of a curve segment at t, with the tightness set by CurveTightness.
+func (c *Canvas) CurvePoint(a, b, c1, d, t float64) float64 {
+	return CardinalPoint(a, b, c1, d, t, c.curveTightness)
+}
+
+// CurveTangent evaluates the derivative of one coordinate of a curve segment at t, with the
+// tightness set by CurveTightness.
+func (c *Canvas) CurveTangent(a, b, c1, d, t float64) float64 {
+	return CardinalTangent(a, b, c1, d, t, c.curveTightness)
+}
+
+// CurveTightness sets the tightness of the curves drawn by Curve and CurveVertex, and evaluated by
+// CurvePoint and CurveTangent.
+func (c *Canvas) CurveTightness(amount float64) {
+	c.curveTightness = amount
+	c.p5Instance.Call("curveTightness", amount)
+}
+
+// BezierDetail sets the resolution of Bezier curves in WEBGL mode.
+func (c *Canvas) BezierDetail(detail int) {
+	c.p5Instance.Call("bezierDetail", detail)
+}
+
+// CurveDetail sets the resolution of curves in WEBGL mode.
+func (c *Canvas) CurveDetail(detail int) {
+	c.p5Instance.Call("curveDetail", detail)
+}
+
+// Spline draws a spline through its points as an open or closed shape made of the given number of segments.
+func (c *Canvas) Spline(s Spline, segments int) {
+	pts := SamplePath(s, segments)
+	if s.Closed && len(pts) > 1 {
+		c.Polygon(pts[:len(pts)-1])
+		return
+	}
+	c.Polyline(pts)
+}
@@ -0,0 +1,152 @@
+package p5go
+
+import (
+	"math"
+	"testing"
+)
+
+// p5CurvePoint and p5CurveTangent are the p5.js curvePoint and curveTangent formulas, with s the
+// value set by curveTightness.
+func p5CurvePoint(a, b, c, d, t, s float64) float64 {
+	t3, t2 := t*t*t, t*t
+	f1 := (s-1)/2*t3 + (1-s)*t2 + (s-1)/2*t
+	f2 := (s+3)/2*t3 + (-5-s)/2*t2 + 1
+	f3 := (-3-s)/2*t3 + (s+2)*t2 + (1-s)/2*t
+	f4 := (1-s)/2*t3 + (s-1)/2*t2
+	return a*f1 + b*f2 + c*f3 + d*f4
+}
+
+func p5CurveTangent(a, b, c, d, t, s float64) float64 {
+	tt3, t2 := t*t*3, t*2
+	f1 := (s-1)/2*tt3 + (1-s)*t2 + (s-1)/2
+	f2 := (s+3)/2*tt3 + (-5-s)/2*t2
+	f3 := (-3-s)/2*tt3 + (s+2)*t2 + (1-s)/2
+	f4 := (1-s)/2*tt3 + (s-1)/2*t2
+	return a*f1 + b*f2 + c*f3 + d*f4
+}
+
+func TestCardinalMatchesP5(t *testing.T) {
+	a, b, c, d := 10.0, 40.0, 25.0, 90.0
+	for _, s := range []float64{-1, -0.5, 0, 0.5, 1} {
+		for _, u := range []float64{0, 0.1, 0.25, 0.5, 0.8, 1} {
+			if got, want := CardinalPoint(a, b, c, d, u, s), p5CurvePoint(a, b, c, d, u, s); math.Abs(got-want) > 1e-9 {
+				t.Errorf("CardinalPoint(t=%v, tightness=%v) = %v, p5 curvePoint = %v", u, s, got, want)
+			}
+			if got, want := CardinalTangent(a, b, c, d, u, s), p5CurveTangent(a, b, c, d, u, s); math.Abs(got-want) > 1e-9 {
+				t.Errorf("CardinalTangent(t=%v, tightness=%v) = %v, p5 curveTangent = %v", u, s, got, want)
+			}
+		}
+	}
+	if got, want := CurvePoint(a, b, c, d, 0.3), p5CurvePoint(a, b, c, d, 0.3, 0); math.Abs(got-want) > 1e-9 {
+		t.Errorf("CurvePoint = %v, p5 curvePoint = %v", got, want)
+	}
+	// a tightness of 1 eases straight from b to c, ignoring a and d
+	if got, want := CardinalPoint(a, b, c, d, 0.25, 1), b+(c-b)*(3*0.25*0.25-2*0.25*0.25*0.25); math.Abs(got-want) > 1e-9 {
+		t.Errorf("CardinalPoint with tightness 1 = %v, want %v", got, want)
+	}
+}
+
+func TestCurveEndpoints(t *testing.T) {
+	bez := CubicBezier{Vector{X: 0, Y: 0}, Vector{X: 30, Y: -40}, Vector{X: 70, Y: 90}, Vector{X: 100, Y: 20}}
+	if p := bez.Point(0); p != bez.P0 {
+		t.Errorf("Bezier Point(0) = %v, want %v", p, bez.P0)
+	}
+	if p := bez.Point(1); p != bez.P3 {
+		t.Errorf("Bezier Point(1) = %v, want %v", p, bez.P3)
+	}
+	// the end tangents point at the control points
+	if tan := bez.Tangent(0); tan != bez.P1.Sub(bez.P0).Mult(3) {
+		t.Errorf("Bezier Tangent(0) = %v, want 3(P1-P0)", tan)
+	}
+	if tan := bez.Tangent(1); tan != bez.P3.Sub(bez.P2).Mult(3) {
+		t.Errorf("Bezier Tangent(1) = %v, want 3(P3-P2)", tan)
+	}
+	if v := CurvePoint(5, 10, 20, 40, 0); v != 10 {
+		t.Errorf("CurvePoint(t=0) = %v, want 10", v)
+	}
+	if v := CurvePoint(5, 10, 20, 40, 1); v != 20 {
+		t.Errorf("CurvePoint(t=1) = %v, want 20", v)
+	}
+
+	points := []Vector{{X: 0, Y: 0}, {X: 50, Y: 20}, {X: 80, Y: 80}, {X: 20, Y: 100}}
+	for _, s := range []Spline{
+		{Points: points},
+		{Points: points, Tightness: 0.5},
+		{Points: points, Closed: true},
+	} {
+		n := s.Segments()
+		for i := 0; i <= n; i++ {
+			want := s.at(i)
+			if p := s.Point(float64(i) / float64(n)); p.Dist(want) > 1e-9 {
+				t.Errorf("spline (closed %v, tightness %v) at knot %d = %v, want %v", s.Closed, s.Tightness, i, p, want)
+			}
+		}
+	}
+	if got := (Spline{Points: points[:1]}).Point(0.5); got != points[0] {
+		t.Errorf("single point spline = %v, want %v", got, points[0])
+	}
+}
+
+func TestCurveTangents(t *testing.T) {
+	const h = 1e-6
+	paths := map[string]Path{
+		"bezier":        CubicBezier{Vector{X: 0, Y: 0}, Vector{X: 30, Y: -40}, Vector{X: 70, Y: 90}, Vector{X: 100, Y: 20}},
+		"spline":        Spline{Points: []Vector{{X: 0, Y: 0}, {X: 50, Y: 20}, {X: 80, Y: 80}, {X: 20, Y: 100}}},
+		"tight spline":  Spline{Points: []Vector{{X: 0, Y: 0}, {X: 50, Y: 20}, {X: 80, Y: 80}}, Tightness: -0.5},
+		"closed spline": Spline{Points: []Vector{{X: 0, Y: 0}, {X: 50, Y: 20}, {X: 80, Y: 80}}, Closed: true},
+	}
+	for name, p := range paths {
+		// the tangent matches the central difference, away from the knots where splines may bend
+		for _, u := range []float64{0.1, 0.3, 0.45, 0.6, 0.9} {
+			num := p.Point(u + h).Sub(p.Point(u - h)).Mult(1 / (2 * h))
+			if tan := p.Tangent(u); tan.Dist(num) > 1e-4*math.Max(1, num.Mag()) {
+				t.Errorf("%s: Tangent(%v) = %v, numerical derivative %v", name, u, tan, num)
+			}
+		}
+	}
+}
+
+func TestArcLength(t *testing.T) {
+	line := CubicBezier{Vector{X: 0, Y: 0}, Vector{X: 10, Y: 0}, Vector{X: 20, Y: 0}, Vector{X: 30, Y: 40}}
+	straight := CubicBezier{Vector{X: 0, Y: 0}, Vector{X: 3, Y: 4}, Vector{X: 6, Y: 8}, Vector{X: 30, Y: 40}}
+	if l := NewArcLength(straight, 100).Length(); math.Abs(l-50) > 1e-9 {
+		t.Errorf("length of a straight curve = %v, want 50", l)
+	}
+
+	arc := NewArcLength(line, 500)
+	pts := arc.EvenPoints(11)
+	if len(pts) != 11 || pts[0] != line.P0 || pts[10].Dist(line.P3) > 1e-9 {
+		t.Fatalf("EvenPoints = %v, want 11 points from P0 to P3", pts)
+	}
+	chord := arc.Length() / 10
+	for i := 1; i < len(pts); i++ {
+		if d := pts[i].Dist(pts[i-1]); math.Abs(d-chord) > 0.01*chord {
+			t.Errorf("even points %d and %d are %v apart, want %v", i-1, i, d, chord)
+		}
+	}
+
+	spaced := arc.Spaced(5)
+	if want := int(arc.Length()/5) + 1; len(spaced) != want {
+		t.Errorf("Spaced(5) returned %d points, want %d", len(spaced), want)
+	}
+	for i := 1; i < len(spaced); i++ {
+		if d := spaced[i].Dist(spaced[i-1]); math.Abs(d-5) > 0.05 {
+			t.Errorf("spaced points %d and %d are %v apart, want 5", i-1, i, d)
+		}
+	}
+
+	if tt := arc.T(-1); tt != 0 {
+		t.Errorf("T before the start = %v, want 0", tt)
+	}
+	if tt := arc.T(arc.Length() + 1); tt != 1 {
+		t.Errorf("T past the end = %v, want 1", tt)
+	}
+	prev := 0.0
+	for d := 1.0; d < arc.Length(); d++ {
+		tt := arc.T(d)
+		if tt <= prev {
+			t.Fatalf("T(%v) = %v is not increasing", d, tt)
+		}
+		prev = tt
+	}
+}
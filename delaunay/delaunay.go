@@ -0,0 +1,391 @@
+// Package delaunay computes Delaunay triangulations and Voronoi diagrams of points.
+package delaunay
+
+import (
+	"math"
+	"sort"
+
+	"github.com/ryomak/p5go"
+)
+
+// Triangulation is the Delaunay triangulation of a set of points.
+// Triangles and Halfedges use the half edge layout of the Delaunator library: half edge e belongs
+// to triangle e/3 and goes from point Triangles[e] to the next point of that triangle.
+type Triangulation struct {
+	Points    []p5go.Vector
+	Triangles []int // point indices, three per triangle
+	Halfedges []int // index of the opposite half edge, -1 on the hull
+	Hull      []int // point indices of the convex hull
+
+	hullPrev, hullNext, hullTri, hullHash []int
+	hullStart                             int
+	center                                p5go.Vector
+}
+
+// Triangulate computes the Delaunay triangulation of points, using the sweep hull algorithm of the
+// Delaunator library. Duplicate points are left out of the triangles.
+// If every point is on a line there are no triangles and Hull holds the points in order.
+func Triangulate(points []p5go.Vector) *Triangulation {
+	t := &Triangulation{Points: points}
+	n := len(points)
+	if n == 0 {
+		return t
+	}
+	t.triangulate()
+	return t
+}
+
+// Len returns the number of triangles.
+func (t *Triangulation) Len() int {
+	return len(t.Triangles) / 3
+}
+
+// Triangle returns the i-th triangle.
+func (t *Triangulation) Triangle(i int) p5go.Triangle {
+	return p5go.Triangle{
+		V1: t.Points[t.Triangles[3*i]],
+		V2: t.Points[t.Triangles[3*i+1]],
+		V3: t.Points[t.Triangles[3*i+2]],
+	}
+}
+
+// Edges returns every edge of the triangulation once.
+func (t *Triangulation) Edges() []p5go.Line {
+	var lines []p5go.Line
+	for e, h := range t.Halfedges {
+		if e > h {
+			lines = append(lines, p5go.Line{Start: t.Points[t.Triangles[e]], End: t.Points[t.Triangles[nextHalfedge(e)]]})
+		}
+	}
+	return lines
+}
+
+// Neighbors returns for each point the indices of the points it shares an edge with.
+func (t *Triangulation) Neighbors() [][]int {
+	adj := make([][]int, len(t.Points))
+	for e, h := range t.Halfedges {
+		a, b := t.Triangles[e], t.Triangles[nextHalfedge(e)]
+		adj[a] = append(adj[a], b)
+		if h == -1 {
+			// hull edges only have one half edge
+			adj[b] = append(adj[b], a)
+		}
+	}
+	if len(t.Triangles) == 0 {
+		for i := 1; i < len(t.Hull); i++ {
+			a, b := t.Hull[i-1], t.Hull[i]
+			adj[a] = append(adj[a], b)
+			adj[b] = append(adj[b], a)
+		}
+	}
+	return adj
+}
+
+// Draw draws the triangles.
+func (t *Triangulation) Draw(r p5go.Renderer) {
+	for i := 0; i < t.Len(); i++ {
+		tr := t.Triangle(i)
+		r.Triangle(tr.V1.X, tr.V1.Y, tr.V2.X, tr.V2.Y, tr.V3.X, tr.V3.Y)
+	}
+}
+
+// DrawHull draws the convex hull.
+func (t *Triangulation) DrawHull(r p5go.Renderer) {
+	pts := make([]p5go.Vector, len(t.Hull))
+	for i, p := range t.Hull {
+		pts[i] = t.Points[p]
+	}
+	p5go.DrawPolygon(r, pts)
+}
+
+func nextHalfedge(e int) int {
+	if e%3 == 2 {
+		return e - 2
+	}
+	return e + 1
+}
+
+// duplicateEpsilon is the distance below which consecutive points of the sweep are treated as duplicates.
+const duplicateEpsilon = 0x1p-52
+
+func (t *Triangulation) triangulate() {
+	pts := t.Points
+	n := len(pts)
+	bounds := p5go.BoundingBox(pts)
+	c := bounds.Center()
+
+	// seed triangle: the point closest to the center, its nearest neighbour and the point making
+	// the smallest circumcircle with them
+	i0, i1, i2 := 0, -1, -1
+	minDist := math.Inf(1)
+	for i, p := range pts {
+		if d := p.Sub(c).MagSq(); d < minDist {
+			i0, minDist = i, d
+		}
+	}
+	minDist = math.Inf(1)
+	for i, p := range pts {
+		if d := p.Sub(pts[i0]).MagSq(); i != i0 && d > 0 && d < minDist {
+			i1, minDist = i, d
+		}
+	}
+	minRadius := math.Inf(1)
+	if i1 >= 0 {
+		for i, p := range pts {
+			if i == i0 || i == i1 {
+				continue
+			}
+			if r := circumradius(pts[i0], pts[i1], p); r < minRadius {
+				i2, minRadius = i, r
+			}
+		}
+	}
+
+	ids := make([]int, n)
+	for i := range ids {
+		ids[i] = i
+	}
+	dists := make([]float64, n)
+
+	if math.IsInf(minRadius, 1) {
+		// collinear points: order them along the line
+		for i, p := range pts {
+			dists[i] = p.X - pts[0].X
+			if dists[i] == 0 {
+				dists[i] = p.Y - pts[0].Y
+			}
+		}
+		sort.SliceStable(ids, func(a, b int) bool { return dists[ids[a]] < dists[ids[b]] })
+		d0 := math.Inf(-1)
+		for _, i := range ids {
+			if dists[i] > d0 {
+				t.Hull = append(t.Hull, i)
+				d0 = dists[i]
+			}
+		}
+		return
+	}
+
+	if orient(pts[i0], pts[i1], pts[i2]) {
+		i1, i2 = i2, i1
+	}
+	t.center = circumcenter(pts[i0], pts[i1], pts[i2])
+	for i, p := range pts {
+		dists[i] = p.Sub(t.center).MagSq()
+	}
+	sort.Slice(ids, func(a, b int) bool { return dists[ids[a]] < dists[ids[b]] })
+
+	hashSize := int(math.Ceil(math.Sqrt(float64(n))))
+	t.hullPrev = make([]int, n)
+	t.hullNext = make([]int, n)
+	t.hullTri = make([]int, n)
+	t.hullHash = make([]int, hashSize)
+	for i := range t.hullHash {
+		t.hullHash[i] = -1
+	}
+	maxTriangles := max(2*n-5, 1)
+	t.Triangles = make([]int, 0, maxTriangles*3)
+	t.Halfedges = make([]int, 0, maxTriangles*3)
+
+	hullPrev, hullNext, hullTri := t.hullPrev, t.hullNext, t.hullTri
+	t.hullStart = i0
+	hullSize := 3
+	hullNext[i0], hullPrev[i2] = i1, i1
+	hullNext[i1], hullPrev[i0] = i2, i2
+	hullNext[i2], hullPrev[i1] = i0, i0
+	hullTri[i0], hullTri[i1], hullTri[i2] = 0, 1, 2
+	t.hullHash[t.hashKey(pts[i0])] = i0
+	t.hullHash[t.hashKey(pts[i1])] = i1
+	t.hullHash[t.hashKey(pts[i2])] = i2
+	t.addTriangle(i0, i1, i2, -1, -1, -1)
+
+	var prev p5go.Vector
+	for k, i := range ids {
+		p := pts[i]
+		if k > 0 && math.Abs(p.X-prev.X) <= duplicateEpsilon && math.Abs(p.Y-prev.Y) <= duplicateEpsilon {
+			continue
+		}
+		prev = p
+		if i == i0 || i == i1 || i == i2 {
+			continue
+		}
+
+		// find a visible edge of the hull using the angle hash
+		start := 0
+		key := t.hashKey(p)
+		for j := 0; j < hashSize; j++ {
+			start = t.hullHash[(key+j)%hashSize]
+			if start != -1 && start != hullNext[start] {
+				break
+			}
+		}
+		start = hullPrev[start]
+		e := start
+		for !orient(p, pts[e], pts[hullNext[e]]) {
+			e = hullNext[e]
+			if e == start {
+				e = -1
+				break
+			}
+		}
+		if e == -1 {
+			// likely a near duplicate point
+			continue
+		}
+
+		// add the first triangle from the point and flip until the triangles are Delaunay
+		tr := t.addTriangle(e, i, hullNext[e], -1, -1, hullTri[e])
+		hullTri[i] = t.legalize(tr + 2)
+		hullTri[e] = tr
+		hullSize++
+
+		// walk forward through the hull, adding more triangles
+		nx := hullNext[e]
+		for q := hullNext[nx]; orient(p, pts[nx], pts[q]); q = hullNext[nx] {
+			tr = t.addTriangle(nx, i, q, hullTri[i], -1, hullTri[nx])
+			hullTri[i] = t.legalize(tr + 2)
+			hullNext[nx] = nx // removed from the hull
+			hullSize--
+			nx = q
+		}
+
+		// walk backward from the other side
+		if e == start {
+			for q := hullPrev[e]; orient(p, pts[q], pts[e]); q = hullPrev[e] {
+				tr = t.addTriangle(q, i, e, -1, hullTri[e], hullTri[q])
+				t.legalize(tr + 2)
+				hullTri[q] = tr
+				hullNext[e] = e
+				hullSize--
+				e = q
+			}
+		}
+
+		t.hullStart, hullPrev[i] = e, e
+		hullNext[e], hullPrev[nx] = i, i
+		hullNext[i] = nx
+		t.hullHash[t.hashKey(p)] = i
+		t.hullHash[t.hashKey(pts[e])] = e
+	}
+
+	t.Hull = make([]int, hullSize)
+	for i, e := 0, t.hullStart; i < hullSize; i++ {
+		t.Hull[i] = e
+		e = hullNext[e]
+	}
+	t.hullPrev, t.hullNext, t.hullTri, t.hullHash = nil, nil, nil, nil
+}
+
+// hashKey maps the angle of p around the center to a bucket of the hull hash.
+func (t *Triangulation) hashKey(p p5go.Vector) int {
+	size := len(t.hullHash)
+	return int(math.Floor(pseudoAngle(p.X-t.center.X, p.Y-t.center.Y)*float64(size))) % size
+}
+
+// legalize flips the edge a and the edges behind it until the triangles around it satisfy the
+// Delaunay condition.
+func (t *Triangulation) legalize(a int) int {
+	var stack []int
+	var ar int
+	for {
+		b := t.Halfedges[a]
+		a0 := a - a%3
+		ar = a0 + (a+2)%3
+		if b == -1 {
+			if len(stack) == 0 {
+				break
+			}
+			a, stack = stack[len(stack)-1], stack[:len(stack)-1]
+			continue
+		}
+		b0 := b - b%3
+		al := a0 + (a+1)%3
+		bl := b0 + (b+2)%3
+		p0, pr, pl, p1 := t.Triangles[ar], t.Triangles[a], t.Triangles[al], t.Triangles[bl]
+		if !inCircle(t.Points[p0], t.Points[pr], t.Points[pl], t.Points[p1]) {
+			if len(stack) == 0 {
+				break
+			}
+			a, stack = stack[len(stack)-1], stack[:len(stack)-1]
+			continue
+		}
+		t.Triangles[a] = p1
+		t.Triangles[b] = p0
+		hbl := t.Halfedges[bl]
+		if hbl == -1 {
+			// the flipped edge was on the hull: fix the hull triangle reference
+			e := t.hullStart
+			for {
+				if t.hullTri[e] == bl {
+					t.hullTri[e] = a
+					break
+				}
+				e = t.hullPrev[e]
+				if e == t.hullStart {
+					break
+				}
+			}
+		}
+		t.link(a, hbl)
+		t.link(b, t.Halfedges[ar])
+		t.link(ar, bl)
+		stack = append(stack, b0+(b+1)%3)
+	}
+	return ar
+}
+
+func (t *Triangulation) link(a, b int) {
+	t.Halfedges[a] = b
+	if b != -1 {
+		t.Halfedges[b] = a
+	}
+}
+
+func (t *Triangulation) addTriangle(i0, i1, i2, a, b, c int) int {
+	e := len(t.Triangles)
+	t.Triangles = append(t.Triangles, i0, i1, i2)
+	t.Halfedges = append(t.Halfedges, -1, -1, -1)
+	t.link(e, a)
+	t.link(e+1, b)
+	t.link(e+2, c)
+	return e
+}
+
+// pseudoAngle returns a value between 0 and 1 that increases with the angle of (dx, dy).
+func pseudoAngle(dx, dy float64) float64 {
+	p := dx / (math.Abs(dx) + math.Abs(dy))
+	if dy > 0 {
+		return (3 - p) / 4
+	}
+	return (1 + p) / 4
+}
+
+func orient(p, q, r p5go.Vector) bool {
+	return (q.Y-p.Y)*(r.X-q.X)-(q.X-p.X)*(r.Y-q.Y) < 0
+}
+
+func inCircle(a, b, c, p p5go.Vector) bool {
+	d, e, f := a.Sub(p), b.Sub(p), c.Sub(p)
+	ap, bp, cp := d.MagSq(), e.MagSq(), f.MagSq()
+	return d.X*(e.Y*cp-bp*f.Y)-d.Y*(e.X*cp-bp*f.X)+ap*(e.X*f.Y-e.Y*f.X) < 0
+}
+
+// circumOffset returns the circumcenter of the triangle relative to a.
+func circumOffset(a, b, c p5go.Vector) p5go.Vector {
+	d, e := b.Sub(a), c.Sub(a)
+	bl, cl := d.MagSq(), e.MagSq()
+	k := 0.5 / d.Cross(e)
+	return p5go.Vector{X: (e.Y*bl - d.Y*cl) * k, Y: (d.X*cl - e.X*bl) * k}
+}
+
+func circumradius(a, b, c p5go.Vector) float64 {
+	r := circumOffset(a, b, c).MagSq()
+	if math.IsNaN(r) {
+		return math.Inf(1)
+	}
+	return r
+}
+
+func circumcenter(a, b, c p5go.Vector) p5go.Vector {
+	return a.Add(circumOffset(a, b, c))
+}
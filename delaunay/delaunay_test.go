@@ -0,0 +1,174 @@
+package delaunay
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/ryomak/p5go"
+)
+
+func randomPoints(n int, seed int64) []p5go.Vector {
+	rng := rand.New(rand.NewSource(seed))
+	points := make([]p5go.Vector, n)
+	for i := range points {
+		points[i] = p5go.Vector{X: rng.Float64() * 500, Y: rng.Float64() * 400}
+	}
+	return points
+}
+
+func TestEmptyCircumcircle(t *testing.T) {
+	for _, seed := range []int64{1, 2, 3} {
+		points := randomPoints(200, seed)
+		tr := Triangulate(points)
+		if tr.Len() == 0 {
+			t.Fatalf("seed %d: no triangles", seed)
+		}
+		for i := 0; i < tr.Len(); i++ {
+			a, b, c := points[tr.Triangles[3*i]], points[tr.Triangles[3*i+1]], points[tr.Triangles[3*i+2]]
+			center, r := circumcenter(a, b, c), math.Sqrt(circumradius(a, b, c))
+			for j, p := range points {
+				if p.Dist(center) < r-1e-6 {
+					t.Fatalf("seed %d: point %d is inside the circumcircle of triangle %d", seed, j, i)
+				}
+			}
+		}
+	}
+}
+
+func TestTriangleCount(t *testing.T) {
+	tests := []struct {
+		name   string
+		points []p5go.Vector
+	}{
+		{"random", randomPoints(300, 4)},
+		{"square and center", []p5go.Vector{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}, {X: 5, Y: 5}}},
+		{"triangle", []p5go.Vector{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 0, Y: 10}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := Triangulate(tt.points)
+			n, h := len(tt.points), len(tr.Hull)
+			if got, want := tr.Len(), 2*n-h-2; got != want {
+				t.Errorf("%d triangles, want 2n-h-2 = %d", got, want)
+			}
+			// every inner half edge has an opposite pointing back to it
+			for e, o := range tr.Halfedges {
+				if o >= 0 && tr.Halfedges[o] != e {
+					t.Errorf("half edge %d: opposite %d points to %d", e, o, tr.Halfedges[o])
+				}
+			}
+		})
+	}
+}
+
+func TestCollinear(t *testing.T) {
+	points := []p5go.Vector{{X: 30, Y: 30}, {X: 0, Y: 0}, {X: 20, Y: 20}, {X: 10, Y: 10}}
+	tr := Triangulate(points)
+	if tr.Len() != 0 {
+		t.Errorf("%d triangles for collinear points, want 0", tr.Len())
+	}
+	if len(tr.Hull) != len(points) {
+		t.Fatalf("hull %v, want every point", tr.Hull)
+	}
+	// the hull lists the points in order along the line
+	for i := 1; i < len(tr.Hull); i++ {
+		d := points[tr.Hull[i]].Dist(points[tr.Hull[i-1]])
+		if math.Abs(d-math.Sqrt(200)) > 1e-9 {
+			t.Errorf("hull %v is not in order along the line", tr.Hull)
+			break
+		}
+	}
+}
+
+func TestDuplicates(t *testing.T) {
+	points := []p5go.Vector{
+		{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}, {X: 5, Y: 5},
+		{X: 5, Y: 5}, {X: 0, Y: 0},
+	}
+	tr := Triangulate(points)
+	if tr.Len() != 4 {
+		t.Errorf("%d triangles, want 4", tr.Len())
+	}
+	used := map[int]bool{}
+	for _, i := range tr.Triangles {
+		used[i] = true
+	}
+	if used[5] && used[4] || used[6] && used[0] {
+		t.Errorf("both copies of a duplicate point are used: %v", tr.Triangles)
+	}
+
+	bounds := p5go.Rectangle{Position: p5go.Vector{X: -5, Y: -5}, Size: p5go.Vector{X: 20, Y: 20}}
+	v := tr.Voronoi(bounds)
+	if v.Cells[4] != nil && v.Cells[5] != nil {
+		t.Errorf("both copies of (5, 5) have a cell")
+	}
+	checkCells(t, v, points, bounds)
+}
+
+func TestVoronoiCells(t *testing.T) {
+	points := randomPoints(100, 5)
+	bounds := p5go.Rectangle{Size: p5go.Vector{X: 500, Y: 400}}
+	checkCells(t, NewVoronoi(points, bounds), points, bounds)
+}
+
+// checkCells checks that the cells cover the bounds exactly, that each cell contains its point and
+// is clockwise on screen, and that Find returns the cell of every point.
+func checkCells(t *testing.T, v *Voronoi, points []p5go.Vector, bounds p5go.Rectangle) {
+	t.Helper()
+	total := 0.0
+	for i, cell := range v.Cells {
+		if cell == nil {
+			continue
+		}
+		if a := p5go.PolygonSignedArea(cell); a <= 0 {
+			t.Errorf("cell %d has a non-positive signed area %v", i, a)
+		}
+		total += p5go.PolygonArea(cell)
+		if !p5go.PointInPolygon(points[i], cell) {
+			t.Errorf("cell %d does not contain its point %v", i, points[i])
+		}
+		if j := v.Find(points[i]); points[j] != points[i] {
+			t.Errorf("Find(%v) = point %d at %v", points[i], j, points[j])
+		}
+	}
+	if want := bounds.Size.X * bounds.Size.Y; math.Abs(total-want) > 1e-6*want {
+		t.Errorf("cells cover %v, want the bounds area %v", total, want)
+	}
+}
+
+func TestRelax(t *testing.T) {
+	bounds := p5go.Rectangle{Size: p5go.Vector{X: 100, Y: 100}}
+	// a tight cluster in one corner
+	rng := rand.New(rand.NewSource(6))
+	points := make([]p5go.Vector, 20)
+	for i := range points {
+		points[i] = p5go.Vector{X: rng.Float64() * 10, Y: rng.Float64() * 10}
+	}
+	v := NewVoronoi(points, bounds)
+	relaxed := Relax(points, bounds, 1)
+	for i, p := range relaxed {
+		if want := p5go.PolygonCentroid(v.Cells[i]); p.Dist(want) > 1e-9 {
+			t.Errorf("point %d moved to %v, want the centroid of its cell %v", i, p, want)
+		}
+		if !bounds.Contains(p) {
+			t.Errorf("point %d moved out of the bounds to %v", i, p)
+		}
+	}
+	if before, after := minDistance(points), minDistance(relaxed); after <= before {
+		t.Errorf("closest pair at %v after relaxing, %v before", after, before)
+	}
+	if got := Relax(points, bounds, 0); &got[0] != &points[0] {
+		t.Errorf("Relax with no iterations changed the points")
+	}
+}
+
+func minDistance(points []p5go.Vector) float64 {
+	d := math.Inf(1)
+	for i := range points {
+		for j := i + 1; j < len(points); j++ {
+			d = math.Min(d, points[i].Dist(points[j]))
+		}
+	}
+	return d
+}
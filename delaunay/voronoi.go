@@ -0,0 +1,116 @@
+package delaunay
+
+import (
+	"github.com/ryomak/p5go"
+)
+
+// Voronoi is the Voronoi diagram of a set of points, clipped to a rectangle.
+type Voronoi struct {
+	Delaunay *Triangulation
+	Bounds   p5go.Rectangle
+	// Cells holds the clockwise outline of the cell of each point, nil for duplicate points and
+	// points whose cell lies outside the bounds.
+	Cells [][]p5go.Vector
+}
+
+// NewVoronoi computes the Voronoi diagram of points, clipped to bounds.
+func NewVoronoi(points []p5go.Vector, bounds p5go.Rectangle) *Voronoi {
+	return Triangulate(points).Voronoi(bounds)
+}
+
+// Voronoi computes the Voronoi diagram of the triangulated points, clipped to bounds.
+// Each cell is the bounds clipped by the bisectors between the point and its Delaunay neighbours.
+func (t *Triangulation) Voronoi(bounds p5go.Rectangle) *Voronoi {
+	bounds = bounds.Bounds()
+	lo, hi := bounds.Min(), bounds.Max()
+	box := []p5go.Vector{lo, {X: hi.X, Y: lo.Y}, hi, {X: lo.X, Y: hi.Y}}
+
+	v := &Voronoi{Delaunay: t, Bounds: bounds, Cells: make([][]p5go.Vector, len(t.Points))}
+	for i, nb := range t.Neighbors() {
+		if len(nb) == 0 && len(t.Points) > 1 {
+			continue
+		}
+		cell := box
+		p := t.Points[i]
+		for _, j := range nb {
+			cell = clipHalfPlane(cell, p, t.Points[j])
+			if len(cell) == 0 {
+				break
+			}
+		}
+		if len(cell) >= 3 {
+			v.Cells[i] = cell
+		}
+	}
+	return v
+}
+
+// Cell returns the outline of the cell of point i.
+func (v *Voronoi) Cell(i int) []p5go.Vector {
+	return v.Cells[i]
+}
+
+// Find returns the index of the point whose cell contains p, or -1 if there are no cells.
+func (v *Voronoi) Find(p p5go.Vector) int {
+	best, d := -1, 0.0
+	for i, q := range v.Delaunay.Points {
+		if v.Cells[i] == nil {
+			continue
+		}
+		if di := q.Sub(p).MagSq(); best < 0 || di < d {
+			best, d = i, di
+		}
+	}
+	return best
+}
+
+// Draw draws every cell.
+func (v *Voronoi) Draw(r p5go.Renderer) {
+	for _, cell := range v.Cells {
+		if cell != nil {
+			p5go.DrawPolygon(r, cell)
+		}
+	}
+}
+
+// Centroids returns the centroid of each cell, or the point itself if it has no cell.
+func (v *Voronoi) Centroids() []p5go.Vector {
+	out := make([]p5go.Vector, len(v.Cells))
+	for i, cell := range v.Cells {
+		if cell == nil {
+			out[i] = v.Delaunay.Points[i]
+			continue
+		}
+		out[i] = p5go.PolygonCentroid(cell)
+	}
+	return out
+}
+
+// Relax applies the given number of Lloyd relaxation steps, moving every point to the centroid of
+// its Voronoi cell. This spreads the points out evenly over bounds. It returns the new points.
+func Relax(points []p5go.Vector, bounds p5go.Rectangle, iterations int) []p5go.Vector {
+	for i := 0; i < iterations; i++ {
+		points = NewVoronoi(points, bounds).Centroids()
+	}
+	return points
+}
+
+// clipHalfPlane keeps the part of the polygon closer to p than to q.
+func clipHalfPlane(poly []p5go.Vector, p, q p5go.Vector) []p5go.Vector {
+	mid := p.Lerp(q, 0.5)
+	n := q.Sub(p)
+	side := func(v p5go.Vector) float64 { return v.Sub(mid).Dot(n) }
+
+	out := make([]p5go.Vector, 0, len(poly)+1)
+	for i, a := range poly {
+		b := poly[(i+1)%len(poly)]
+		sa, sb := side(a), side(b)
+		if sa <= 0 {
+			out = append(out, a)
+		}
+		if sa < 0 && sb > 0 || sa > 0 && sb < 0 {
+			out = append(out, a.Lerp(b, sa/(sa-sb)))
+		}
+	}
+	return out
+}
@@ -0,0 +1,149 @@
+//go:build js && wasm
+
+package p5go
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"math"
+	"strings"
+	"syscall/js"
+)
+
+// maxExportTileSide is the largest backing canvas side used while exporting, in pixels.
+const maxExportTileSide = 4096
+
+// PixelDensity sets the pixel density of the canvas.
+func (c *Canvas) PixelDensity(density float64) {
+	c.p5Instance.Call("pixelDensity", density)
+}
+
+// GetPixelDensity returns the current pixel density of the canvas.
+func (c *Canvas) GetPixelDensity() float64 {
+	return c.p5Instance.Call("pixelDensity").Float()
+}
+
+// DisplayDensity returns the pixel density of the display.
+func (c *Canvas) DisplayDensity() float64 {
+	return c.p5Instance.Call("displayDensity").Float()
+}
+
+// ExportPNG renders the current frame again at scale times the canvas size and downloads it as a PNG file.
+// Large outputs are rendered in tiles, so the size is not limited by the maximum canvas size of the browser.
+// The draw handler runs once per tile with the same frameCount and sketch coordinates, so it must draw
+// the same picture every time it is called for a frame.
+//
+// p5 cannot redraw while the draw handler runs, so when ExportPNG is called from Draw the export of that
+// frame is deferred until the draw handler returns: it runs before the next frame, or right away in a
+// sketch that does not loop. ExportPNG then returns nil and errors of the export are logged to the console.
+func (c *Canvas) ExportPNG(filename string, scale float64) error {
+	frame := c.FrameCount()
+	if !c.p5Instance.Get("_inUserDraw").Truthy() {
+		return c.exportPNG(filename, scale, frame, true)
+	}
+	export := func(redraw bool) {
+		if err := c.exportPNG(filename, scale, frame, redraw); err != nil {
+			global.Get("console").Call("error", "p5go: ExportPNG: "+err.Error())
+		}
+	}
+	if c.IsLooping() {
+		c.pendingExport = func() { export(false) }
+		return nil
+	}
+	var timeout js.Func
+	timeout = js.FuncOf(func(this js.Value, args []js.Value) any {
+		timeout.Release()
+		export(true)
+		return nil
+	})
+	global.Call("setTimeout", timeout, 0)
+	return nil
+}
+
+// exportPNG exports frame. With redraw, the canvas is redrawn afterwards to show the current frame again;
+// otherwise the caller draws the next frame.
+func (c *Canvas) exportPNG(filename string, scale float64, frame int, redraw bool) error {
+	w, h := c.Width(), c.Height()
+	outW, outH := int(math.Round(w*scale)), int(math.Round(h*scale))
+	n := int(math.Ceil(float64(max(outW, outH)) / maxExportTileSide))
+	if n < 1 {
+		n = 1
+	}
+
+	density := c.GetPixelDensity()
+	current := c.FrameCount()
+	defer func() {
+		c.exportTile = nil
+		c.PixelDensity(density)
+		if redraw {
+			c.p5Instance.Set("frameCount", current-1)
+			c.Redraw()
+		} else {
+			c.p5Instance.Set("frameCount", current)
+		}
+	}()
+
+	c.PixelDensity(scale / float64(n))
+	img := image.NewNRGBA(image.Rect(0, 0, outW, outH))
+	for j := 0; j < n; j++ {
+		for i := 0; i < n; i++ {
+			tx, ty := float64(i)*w/float64(n), float64(j)*h/float64(n)
+			c.exportTile = func() {
+				c.p5Instance.Call("scale", n)
+				c.Translate(-tx, -ty)
+			}
+			c.p5Instance.Set("frameCount", frame-1)
+			c.Redraw()
+			c.copyTile(img, int(math.Round(tx*scale)), int(math.Round(ty*scale)))
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return err
+	}
+	if !strings.HasSuffix(strings.ToLower(filename), ".png") {
+		filename += ".png"
+	}
+	download(filename, "image/png", buf.Bytes())
+	return nil
+}
+
+// copyTile copies the pixels of the canvas element into img at (x, y).
+func (c *Canvas) copyTile(img *image.NRGBA, x, y int) {
+	el := c.p5Instance.Get("canvas")
+	tw, th := el.Get("width").Int(), el.Get("height").Int()
+
+	// drawing onto a 2D canvas reads WEBGL canvases as well
+	tmp := global.Get("document").Call("createElement", "canvas")
+	tmp.Set("width", tw)
+	tmp.Set("height", th)
+	ctx := tmp.Call("getContext", "2d")
+	ctx.Call("drawImage", el, 0, 0)
+	data := ctx.Call("getImageData", 0, 0, tw, th).Get("data")
+
+	pix := make([]byte, tw*th*4)
+	js.CopyBytesToGo(pix, data)
+	tile := &image.NRGBA{Pix: pix, Stride: tw * 4, Rect: image.Rect(0, 0, tw, th)}
+	for row := 0; row < th && y+row < img.Rect.Dy(); row++ {
+		n := min(tw, img.Rect.Dx()-x)
+		if n <= 0 {
+			break
+		}
+		copy(img.Pix[img.PixOffset(x, y+row):], tile.Pix[tile.PixOffset(0, row):tile.PixOffset(0, row)+n*4])
+	}
+}
+
+// download saves data as a file through the browser.
+func download(filename, mime string, data []byte) {
+	arr := global.Get("Uint8Array").New(len(data))
+	js.CopyBytesToJS(arr, data)
+	blob := global.Get("Blob").New([]any{arr}, map[string]any{"type": mime})
+	url := global.Get("URL").Call("createObjectURL", blob)
+	a := global.Get("document").Call("createElement", "a")
+	a.Set("href", url)
+	a.Set("download", filename)
+	a.Call("click")
+	global.Get("URL").Call("revokeObjectURL", url)
+}
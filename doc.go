@@ -0,0 +1,2 @@
+// Package p5go provides a bridge between Go and p5.js.
+package p5go
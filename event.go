@@ -0,0 +1,36 @@
+package p5go
+
+// MouseEvent represents a mouse event.
+// The pointer fields (position, button, modifiers) come from the embedded PointerEvent.
+type MouseEvent struct {
+	PointerEvent
+	Pressed bool
+}
+
+// MouseDraggedEvent represents a mouse dragged event
+type MouseDraggedEvent MouseEvent
+
+// MouseReleasedEvent represents a mouse released event
+type MouseReleasedEvent MouseEvent
+
+// MouseClickedEvent represents a mouse clicked event
+type MouseClickedEvent MouseEvent
+
+// DoubleClickedEvent represents a double clicked event
+type DoubleClickedEvent MouseEvent
+
+// WheelEvent represents a mouse wheel event
+type WheelEvent struct {
+	X, Y                   float64
+	DeltaX, DeltaY         float64
+	DeltaMode              int // 0: pixel, 1: line, 2: page
+	Ctrl, Alt, Shift, Meta bool
+}
+
+// KeyEvent represents a keyboard event
+type KeyEvent struct {
+	Key                    string
+	Code                   KeyCode
+	Ctrl, Alt, Shift, Meta bool
+	Repeat                 bool
+}
@@ -1,3 +1,5 @@
+//go:build js && wasm
+
 package main
 
 import (
@@ -16,8 +18,8 @@ func main() {
 	p5go.Run("main",
 		p5go.Setup(setup),
 		p5go.Draw(draw),
-		p5go.KeyPressed(func(c *p5go.Canvas) {
-			if c.Key() == "s" {
+		p5go.KeyPressed(func(c *p5go.Canvas, e p5go.KeyEvent) {
+			if e.Key == "s" {
 				c.SaveGif("output.gif", 4)
 			}
 
@@ -0,0 +1,103 @@
+// Package flow provides flow fields: grids of vectors that steer particles and trace streamlines.
+package flow
+
+import (
+	"math"
+
+	"github.com/ryomak/p5go"
+)
+
+// FlowField is a vector field sampled on a regular grid. Sample (0, 0) is at the top-left corner
+// of Bounds and sample (Cols-1, Rows-1) at the bottom-right corner; positions in between are
+// interpolated bilinearly.
+type FlowField struct {
+	Bounds     p5go.Rectangle
+	Cols, Rows int
+	Vectors    []p5go.Vector // row by row, Vectors[j*Cols+i] is sample (i, j)
+}
+
+// New creates a field of zero vectors over bounds.
+func New(bounds p5go.Rectangle, cols, rows int) *FlowField {
+	return &FlowField{Bounds: bounds.Bounds(), Cols: cols, Rows: rows, Vectors: make([]p5go.Vector, cols*rows)}
+}
+
+// FromFunc creates a field by evaluating f at every sample position.
+func FromFunc(bounds p5go.Rectangle, cols, rows int, f func(p p5go.Vector) p5go.Vector) *FlowField {
+	ff := New(bounds, cols, rows)
+	for j := 0; j < rows; j++ {
+		for i := 0; i < cols; i++ {
+			ff.Vectors[j*cols+i] = f(ff.Point(i, j))
+		}
+	}
+	return ff
+}
+
+// FromAngles creates a field of unit vectors pointing at the angle returned by f, in radians.
+// For a noise field pass a function such as
+//
+//	func(p p5go.Vector) float64 { return c.Noise(p.X*0.01, p.Y*0.01) * p5go.TWO_PI }
+func FromAngles(bounds p5go.Rectangle, cols, rows int, f func(p p5go.Vector) float64) *FlowField {
+	return FromFunc(bounds, cols, rows, func(p p5go.Vector) p5go.Vector {
+		return p5go.FromAngle(f(p), 1)
+	})
+}
+
+// At returns sample (i, j).
+func (f *FlowField) At(i, j int) p5go.Vector {
+	return f.Vectors[j*f.Cols+i]
+}
+
+// Set sets sample (i, j).
+func (f *FlowField) Set(i, j int, v p5go.Vector) {
+	f.Vectors[j*f.Cols+i] = v
+}
+
+// Point returns the position of sample (i, j).
+func (f *FlowField) Point(i, j int) p5go.Vector {
+	return p5go.Vector{
+		X: f.Bounds.Position.X + f.Bounds.Size.X*float64(i)/float64(max(1, f.Cols-1)),
+		Y: f.Bounds.Position.Y + f.Bounds.Size.Y*float64(j)/float64(max(1, f.Rows-1)),
+	}
+}
+
+// Sample returns the field at p, interpolated bilinearly between the four surrounding samples.
+// Positions outside the bounds use the nearest edge.
+func (f *FlowField) Sample(p p5go.Vector) p5go.Vector {
+	if len(f.Vectors) == 0 {
+		return p5go.Vector{}
+	}
+	gx := clamp((p.X-f.Bounds.Position.X)/f.Bounds.Size.X*float64(f.Cols-1), 0, float64(f.Cols-1))
+	gy := clamp((p.Y-f.Bounds.Position.Y)/f.Bounds.Size.Y*float64(f.Rows-1), 0, float64(f.Rows-1))
+	if math.IsNaN(gx) {
+		gx = 0
+	}
+	if math.IsNaN(gy) {
+		gy = 0
+	}
+	i0, j0 := int(gx), int(gy)
+	i1, j1 := min(i0+1, f.Cols-1), min(j0+1, f.Rows-1)
+	tx, ty := gx-float64(i0), gy-float64(j0)
+	top := f.At(i0, j0).Lerp(f.At(i1, j0), tx)
+	bottom := f.At(i0, j1).Lerp(f.At(i1, j1), tx)
+	return top.Lerp(bottom, ty)
+}
+
+// Angle returns the direction of the field at p, in radians.
+func (f *FlowField) Angle(p p5go.Vector) float64 {
+	return f.Sample(p).Heading()
+}
+
+// Draw draws an arrow of the given length along the field at every sample, for debugging.
+func (f *FlowField) Draw(r p5go.Renderer, length float64) {
+	for j := 0; j < f.Rows; j++ {
+		for i := 0; i < f.Cols; i++ {
+			p := f.Point(i, j)
+			d := f.At(i, j).SetMag(length)
+			p5go.DrawArrow(r, p.X, p.Y, p.X+d.X, p.Y+d.Y, length/4)
+		}
+	}
+}
+
+func clamp(v, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, v))
+}
@@ -0,0 +1,150 @@
+package flow
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ryomak/p5go"
+)
+
+var bounds = p5go.Rectangle{Size: p5go.Vector{X: 100, Y: 100}}
+
+func constant(v p5go.Vector) func(p p5go.Vector) p5go.Vector {
+	return func(p p5go.Vector) p5go.Vector { return v }
+}
+
+func TestSample(t *testing.T) {
+	// a field that is linear in x and y is reproduced exactly between the samples
+	linear := FromFunc(bounds, 11, 6, func(p p5go.Vector) p5go.Vector {
+		return p5go.Vector{X: p.X / 10, Y: 2*p.Y + p.X}
+	})
+	// a single cell with the value 1 at its bottom-right corner only
+	corner := New(p5go.Rectangle{Size: p5go.Vector{X: 10, Y: 10}}, 2, 2)
+	corner.Set(1, 1, p5go.Vector{X: 1, Y: 1})
+	tests := []struct {
+		name  string
+		field *FlowField
+		p     p5go.Vector
+		want  p5go.Vector
+	}{
+		{"at a node", linear, p5go.Vector{X: 20, Y: 40}, p5go.Vector{X: 2, Y: 100}},
+		{"between nodes", linear, p5go.Vector{X: 25, Y: 33}, p5go.Vector{X: 2.5, Y: 91}},
+		{"corner node", linear, p5go.Vector{X: 100, Y: 100}, p5go.Vector{X: 10, Y: 300}},
+		{"outside clamps to the edge", linear, p5go.Vector{X: -50, Y: 150}, p5go.Vector{X: 0, Y: 200}},
+		{"cell center", corner, p5go.Vector{X: 5, Y: 5}, p5go.Vector{X: 0.25, Y: 0.25}},
+		{"cell edge", corner, p5go.Vector{X: 10, Y: 5}, p5go.Vector{X: 0.5, Y: 0.5}},
+		{"bilinear, not linear", corner, p5go.Vector{X: 2.5, Y: 7.5}, p5go.Vector{X: 0.1875, Y: 0.1875}},
+		{"empty field", &FlowField{Bounds: bounds}, p5go.Vector{X: 5, Y: 5}, p5go.Vector{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.field.Sample(tt.p); got.Dist(tt.want) > 1e-9 {
+				t.Errorf("Sample(%v) = %v, want %v", tt.p, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTrace(t *testing.T) {
+	right := FromFunc(bounds, 5, 5, constant(p5go.Vector{X: 3}))
+	tests := []struct {
+		name  string
+		field *FlowField
+		start p5go.Vector
+		step  float64
+		steps int
+		want  []p5go.Vector
+	}{
+		{
+			name: "along the field", field: right, start: p5go.Vector{X: 10, Y: 50}, step: 2, steps: 3,
+			want: []p5go.Vector{{X: 10, Y: 50}, {X: 12, Y: 50}, {X: 14, Y: 50}, {X: 16, Y: 50}},
+		},
+		{
+			name: "against the field", field: right, start: p5go.Vector{X: 10, Y: 50}, step: -4, steps: 2,
+			want: []p5go.Vector{{X: 10, Y: 50}, {X: 6, Y: 50}, {X: 2, Y: 50}},
+		},
+		{
+			name: "stops at the bounds", field: right, start: p5go.Vector{X: 95, Y: 50}, step: 2, steps: 10,
+			want: []p5go.Vector{{X: 95, Y: 50}, {X: 97, Y: 50}, {X: 99, Y: 50}},
+		},
+		{
+			name: "stops where the field is zero", field: New(bounds, 5, 5), start: p5go.Vector{X: 50, Y: 50}, step: 2, steps: 10,
+			want: []p5go.Vector{{X: 50, Y: 50}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.field.Trace(tt.start, tt.step, tt.steps)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Trace = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i].Dist(tt.want[i]) > 1e-9 {
+					t.Fatalf("Trace = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+
+	// the midpoint method keeps a line of a rotating field close to its circle
+	center := p5go.Vector{X: 50, Y: 50}
+	swirl := FromFunc(bounds, 41, 41, func(p p5go.Vector) p5go.Vector {
+		d := p.Sub(center)
+		return p5go.Vector{X: -d.Y, Y: d.X}
+	})
+	for _, p := range swirl.Trace(p5go.Vector{X: 80, Y: 50}, 1, 180) {
+		if d := p.Dist(center); math.Abs(d-30) > 0.5 {
+			t.Fatalf("swirl line at %v is %v from the center, want 30", p, d)
+		}
+	}
+}
+
+func TestStreamlines(t *testing.T) {
+	const sep = 10
+	field := FromFunc(bounds, 5, 5, constant(p5go.Vector{X: 1}))
+	lines := field.Streamlines(StreamlineOptions{Separation: sep})
+	if len(lines) != 100/sep+1 {
+		t.Fatalf("%d lines, want %d from y = 0 to y = 100", len(lines), 100/sep+1)
+	}
+	ys := make([]float64, len(lines))
+	for i, line := range lines {
+		ys[i] = line[0].Y
+		for _, p := range line {
+			if math.Abs(p.Y-ys[i]) > 1e-9 {
+				t.Fatalf("line %d is not straight: %v", i, line)
+			}
+		}
+		if n := len(line); line[n-1].X-line[0].X < 90 {
+			t.Errorf("line %d spans x = %v to %v, want the width of the bounds", i, line[0].X, line[n-1].X)
+		}
+	}
+	for i := range ys {
+		for j := i + 1; j < len(ys); j++ {
+			if d := math.Abs(ys[i] - ys[j]); d < sep-1e-9 {
+				t.Errorf("lines %d and %d are %v apart, want at least %v", i, j, d, float64(sep))
+			}
+		}
+	}
+
+	// on a curved field every point keeps Test x Separation from the other lines
+	center := p5go.Vector{X: 50, Y: 50}
+	swirl := FromFunc(bounds, 21, 21, func(p p5go.Vector) p5go.Vector {
+		d := p.Sub(center)
+		return p5go.Vector{X: -d.Y, Y: d.X}
+	})
+	lines = swirl.Streamlines(StreamlineOptions{Separation: sep})
+	if len(lines) < 3 {
+		t.Fatalf("%d lines on the swirl, want more", len(lines))
+	}
+	for i, a := range lines {
+		for j := i + 1; j < len(lines); j++ {
+			for _, p := range a {
+				for _, q := range lines[j] {
+					if d := p.Dist(q); d < sep*0.5-1e-9 {
+						t.Fatalf("lines %d and %d come %v close, want at least %v", i, j, d, sep*0.5)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package flow
+
+import (
+	"math"
+
+	"github.com/ryomak/p5go"
+	"github.com/ryomak/p5go/spatial"
+)
+
+// Trace follows the field from start for at most steps steps of the given length, using the
+// midpoint method on the normalized field. A negative step traces against the field.
+// It stops early at the bounds and where the field is zero.
+func (f *FlowField) Trace(start p5go.Vector, step float64, steps int) []p5go.Vector {
+	line := []p5go.Vector{start}
+	p := start
+	for i := 0; i < steps; i++ {
+		next, ok := f.advance(p, step)
+		if !ok {
+			break
+		}
+		line = append(line, next)
+		p = next
+	}
+	return line
+}
+
+// advance moves p by step along the field with the midpoint method.
+func (f *FlowField) advance(p p5go.Vector, step float64) (p5go.Vector, bool) {
+	d := f.Sample(p).Normalize()
+	if d == (p5go.Vector{}) {
+		return p, false
+	}
+	mid := p.Add(d.Mult(step / 2))
+	dm := f.Sample(mid).Normalize()
+	if dm == (p5go.Vector{}) {
+		return p, false
+	}
+	next := p.Add(dm.Mult(step))
+	return next, f.Bounds.Contains(next)
+}
+
+// StreamlineOptions configures Streamlines.
+type StreamlineOptions struct {
+	// Separation is the distance between neighbouring streamlines.
+	Separation float64
+	// Test is the fraction of Separation at which a growing line stops near another one, 0.5 if zero.
+	Test float64
+	// Step is the integration step, Separation / 4 if zero.
+	Step float64
+	// MaxSteps limits the length of each half of a line, 1000 if zero.
+	MaxSteps int
+	// MinPoints drops lines with fewer points, 2 if zero.
+	MinPoints int
+	// Seeds are the starting points tried first, the center of the bounds if empty.
+	// A grid of seeds is tried last to fill the remaining space.
+	Seeds []p5go.Vector
+}
+
+// sample identifies a point of a streamline in the spatial index.
+type sample struct {
+	line, index int
+}
+
+// Streamlines traces evenly spaced streamlines that fill the bounds, using the algorithm of Jobard
+// and Lefer: each line grows in both directions until it comes closer than Test x Separation to
+// another line or itself, and new lines start at Separation beside the points of finished lines.
+func (f *FlowField) Streamlines(opts StreamlineOptions) [][]p5go.Vector {
+	if opts.Separation <= 0 {
+		return nil
+	}
+	if opts.Test <= 0 {
+		opts.Test = 0.5
+	}
+	if opts.Step <= 0 {
+		opts.Step = opts.Separation / 4
+	}
+	if opts.MaxSteps <= 0 {
+		opts.MaxSteps = 1000
+	}
+	if opts.MinPoints <= 0 {
+		opts.MinPoints = 2
+	}
+	seeds := append([]p5go.Vector{}, opts.Seeds...)
+	if len(seeds) == 0 {
+		seeds = append(seeds, f.Bounds.Center())
+	}
+
+	grid := spatial.NewGrid[sample](opts.Separation)
+	var buf []spatial.Item[sample]
+	// free reports whether p is at least dist away from every line, ignoring the last points of
+	// the line being traced, which are always close. A seed exactly Separation beside a line is free.
+	free := func(p p5go.Vector, dist float64, line, index int) bool {
+		buf = grid.QueryCircle(p5go.Circle{Position: p, Diameter: 2 * dist}, buf[:0])
+		recent := int(math.Ceil(dist/opts.Step)) + 1
+		for _, it := range buf {
+			if it.Position.Dist(p) >= dist {
+				continue
+			}
+			if it.Value.line != line || abs(it.Value.index-index) > recent {
+				return false
+			}
+		}
+		return true
+	}
+
+	var lines [][]p5go.Vector
+	filled := false
+	for queue := 0; queue < len(seeds) || !filled; queue++ {
+		if queue == len(seeds) {
+			// out of candidates: try a grid of seeds so areas cut off by zeros of the field are filled too
+			filled = true
+			lo := f.Bounds.Min()
+			for y := lo.Y + opts.Separation/2; y < lo.Y+f.Bounds.Size.Y; y += opts.Separation {
+				for x := lo.X + opts.Separation/2; x < lo.X+f.Bounds.Size.X; x += opts.Separation {
+					seeds = append(seeds, p5go.Vector{X: x, Y: y})
+				}
+			}
+			if queue == len(seeds) {
+				break
+			}
+		}
+		seed := seeds[queue]
+		if !f.Bounds.Contains(seed) || !free(seed, opts.Separation, -1, 0) {
+			continue
+		}
+		id := len(lines)
+		line, origin := f.grow(seed, opts, id, free, grid)
+		if len(line) < opts.MinPoints {
+			for i, p := range line {
+				grid.Remove(p, sample{line: id, index: i - origin})
+			}
+			continue
+		}
+		lines = append(lines, line)
+		// candidate seeds beside every point of the new line
+		for i, p := range line {
+			n := f.Sample(p).Normalize()
+			n = p5go.Vector{X: -n.Y, Y: n.X}.Mult(opts.Separation)
+			if i%2 == 0 {
+				seeds = append(seeds, p.Add(n), p.Sub(n))
+			}
+		}
+	}
+	return lines
+}
+
+// grow traces a line from seed in both directions, indexing its points as it goes.
+// It returns the line and the position of the seed in it.
+func (f *FlowField) grow(seed p5go.Vector, opts StreamlineOptions, id int, free func(p p5go.Vector, dist float64, line, index int) bool, grid *spatial.Grid[sample]) ([]p5go.Vector, int) {
+	test := opts.Separation * opts.Test
+	half := func(step float64, sign int) []p5go.Vector {
+		var pts []p5go.Vector
+		p := seed
+		for i := 1; i <= opts.MaxSteps; i++ {
+			next, ok := f.advance(p, step)
+			if !ok || !free(next, test, id, sign*i) {
+				break
+			}
+			grid.Insert(next, sample{line: id, index: sign * i})
+			pts = append(pts, next)
+			p = next
+		}
+		return pts
+	}
+	grid.Insert(seed, sample{line: id})
+	forward := half(opts.Step, 1)
+	backward := half(-opts.Step, -1)
+
+	line := make([]p5go.Vector, 0, len(backward)+1+len(forward))
+	for i := len(backward) - 1; i >= 0; i-- {
+		line = append(line, backward[i])
+	}
+	line = append(line, seed)
+	return append(line, forward...), len(backward)
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
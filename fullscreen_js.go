@@ -0,0 +1,112 @@
+//go:build js && wasm
+
+package p5go
+
+import (
+	"syscall/js"
+)
+
+type screenState struct {
+	watching           bool
+	fullscreenHandler  func(c *Canvas, fullscreen bool)
+	pointerLockHandler func(c *Canvas, locked bool)
+	// size before entering fullscreen when the canvas has no responsive mode
+	restore *Vector
+}
+
+// FullscreenChanged sets the handler called when the sketch enters or exits fullscreen.
+func FullscreenChanged(handler func(c *Canvas, fullscreen bool)) Func {
+	return func(c *Canvas) {
+		c.screen.fullscreenHandler = handler
+		c.watchScreen()
+	}
+}
+
+// PointerLockChanged sets the handler called when the pointer is locked or unlocked.
+func PointerLockChanged(handler func(c *Canvas, locked bool)) Func {
+	return func(c *Canvas) {
+		c.screen.pointerLockHandler = handler
+		c.watchScreen()
+	}
+}
+
+// Fullscreen makes the sketch container fullscreen or exits fullscreen.
+// Browsers only allow entering fullscreen from a user input handler such as MousePressed.
+// The canvas is laid out with its responsive mode; without one it fills the screen until fullscreen ends.
+func (c *Canvas) Fullscreen(on bool) {
+	c.watchScreen()
+	if on == c.IsFullscreen() {
+		return
+	}
+	if on {
+		c.container.Call("requestFullscreen")
+	} else {
+		global.Get("document").Call("exitFullscreen")
+	}
+}
+
+// IsFullscreen returns true if the sketch is fullscreen.
+func (c *Canvas) IsFullscreen() bool {
+	return global.Get("document").Get("fullscreenElement").Truthy()
+}
+
+// RequestPointerLock locks the pointer to the canvas and hides it.
+// While locked, MovedX and MovedY report the relative movement of the mouse.
+func (c *Canvas) RequestPointerLock() {
+	c.watchScreen()
+	c.p5Instance.Call("requestPointerLock")
+}
+
+// ExitPointerLock releases the pointer lock.
+func (c *Canvas) ExitPointerLock() {
+	c.p5Instance.Call("exitPointerLock")
+}
+
+// IsPointerLocked returns true if the pointer is locked to the canvas.
+func (c *Canvas) IsPointerLocked() bool {
+	el := global.Get("document").Get("pointerLockElement")
+	return el.Truthy() && el.Equal(c.p5Instance.Get("canvas"))
+}
+
+// watchScreen listens to the fullscreen and pointer lock changes once.
+func (c *Canvas) watchScreen() {
+	if c.screen.watching {
+		return
+	}
+	c.screen.watching = true
+	doc := global.Get("document")
+	doc.Call("addEventListener", "fullscreenchange", js.FuncOf(func(value js.Value, args []js.Value) any {
+		c.fullscreenChanged()
+		return nil
+	}))
+	doc.Call("addEventListener", "pointerlockchange", js.FuncOf(func(value js.Value, args []js.Value) any {
+		if h := c.screen.pointerLockHandler; h != nil {
+			h(c, c.IsPointerLocked())
+		}
+		return nil
+	}))
+}
+
+func (c *Canvas) fullscreenChanged() {
+	on := c.IsFullscreen()
+	switch {
+	case on && c.responsive == nil:
+		c.screen.restore = &Vector{X: c.Width(), Y: c.Height()}
+		c.responsive = &responsive{mode: ResizeFill}
+		c.layout()
+	case !on && c.screen.restore != nil:
+		size := *c.screen.restore
+		c.screen.restore = nil
+		c.responsive = nil
+		// resizeCanvas also restores the CSS size of the canvas
+		c.ResizeCanvas(int(size.X), int(size.Y))
+		style := c.p5Instance.Get("canvas").Get("style")
+		style.Set("marginLeft", "")
+		style.Set("marginTop", "")
+	default:
+		c.layout()
+	}
+	if h := c.screen.fullscreenHandler; h != nil {
+		h(c, on)
+	}
+}
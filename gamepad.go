@@ -0,0 +1,192 @@
+package p5go
+
+import (
+	"math"
+	"sort"
+)
+
+// Button indices of the "standard" gamepad mapping.
+// https://w3c.github.io/gamepad/#remapping
+const (
+	GamepadButtonA          = 0
+	GamepadButtonB          = 1
+	GamepadButtonX          = 2
+	GamepadButtonY          = 3
+	GamepadButtonLB         = 4
+	GamepadButtonRB         = 5
+	GamepadButtonLT         = 6
+	GamepadButtonRT         = 7
+	GamepadButtonSelect     = 8
+	GamepadButtonStart      = 9
+	GamepadButtonLeftStick  = 10
+	GamepadButtonRightStick = 11
+	GamepadButtonDPadUp     = 12
+	GamepadButtonDPadDown   = 13
+	GamepadButtonDPadLeft   = 14
+	GamepadButtonDPadRight  = 15
+	GamepadButtonHome       = 16
+)
+
+// Axis indices of the "standard" gamepad mapping.
+const (
+	GamepadAxisLeftX  = 0
+	GamepadAxisLeftY  = 1
+	GamepadAxisRightX = 2
+	GamepadAxisRightY = 3
+)
+
+const (
+	// GamepadMappingStandard is the Mapping of gamepads using the standard layout.
+	GamepadMappingStandard = "standard"
+	// DefaultGamepadDeadZone is the dead zone used by a new GamepadPoller.
+	DefaultGamepadDeadZone = 0.1
+)
+
+// GamepadButton represents the state of a single gamepad button
+type GamepadButton struct {
+	Pressed bool
+	Touched bool
+	Value   float64
+}
+
+// Gamepad represents a snapshot of a connected gamepad
+type Gamepad struct {
+	Index     int
+	ID        string
+	Mapping   string
+	Connected bool
+	Buttons   []GamepadButton
+	Axes      []float64
+	Timestamp float64
+
+	prev     []GamepadButton
+	deadZone float64
+}
+
+// Pressed returns true if the button is held down.
+func (g Gamepad) Pressed(button int) bool {
+	return button >= 0 && button < len(g.Buttons) && g.Buttons[button].Pressed
+}
+
+// JustPressed returns true if the button went down since the previous poll.
+func (g Gamepad) JustPressed(button int) bool {
+	return g.Pressed(button) && !(button < len(g.prev) && g.prev[button].Pressed)
+}
+
+// JustReleased returns true if the button went up since the previous poll.
+func (g Gamepad) JustReleased(button int) bool {
+	return !g.Pressed(button) && button >= 0 && button < len(g.prev) && g.prev[button].Pressed
+}
+
+// Value returns the analog value of the button between 0 and 1.
+func (g Gamepad) Value(button int) float64 {
+	if button < 0 || button >= len(g.Buttons) {
+		return 0
+	}
+	return g.Buttons[button].Value
+}
+
+// Axis returns the value of the axis between -1 and 1 with the dead zone applied.
+func (g Gamepad) Axis(axis int) float64 {
+	if axis < 0 || axis >= len(g.Axes) {
+		return 0
+	}
+	v := g.Axes[axis]
+	a := math.Abs(v)
+	if a <= g.deadZone {
+		return 0
+	}
+	return math.Copysign(math.Min((a-g.deadZone)/(1-g.deadZone), 1), v)
+}
+
+// Stick returns the position of a stick made of two axes with a radial dead zone applied.
+func (g Gamepad) Stick(xAxis, yAxis int) Vector {
+	if xAxis < 0 || xAxis >= len(g.Axes) || yAxis < 0 || yAxis >= len(g.Axes) {
+		return Vector{}
+	}
+	x, y := g.Axes[xAxis], g.Axes[yAxis]
+	m := math.Hypot(x, y)
+	if m <= g.deadZone {
+		return Vector{}
+	}
+	s := math.Min((m-g.deadZone)/(1-g.deadZone), 1) / m
+	return Vector{X: x * s, Y: y * s}
+}
+
+// GamepadSource provides the raw state of the connected gamepads.
+// Each call must return newly allocated Buttons slices, since the previous ones are kept for edge detection.
+type GamepadSource interface {
+	Gamepads() []Gamepad
+}
+
+// GamepadSourceFunc is an adapter to use an ordinary function as a GamepadSource.
+type GamepadSourceFunc func() []Gamepad
+
+// Gamepads calls f().
+func (f GamepadSourceFunc) Gamepads() []Gamepad {
+	return f()
+}
+
+// GamepadPoller keeps the gamepad state between polls to detect button edges and connections.
+type GamepadPoller struct {
+	// DeadZone is the axis magnitude below which Axis and Stick report 0.
+	DeadZone float64
+
+	src     GamepadSource
+	current []Gamepad
+}
+
+// NewGamepadPoller creates a GamepadPoller reading from src.
+func NewGamepadPoller(src GamepadSource) *GamepadPoller {
+	return &GamepadPoller{
+		DeadZone: DefaultGamepadDeadZone,
+		src:      src,
+	}
+}
+
+// Poll reads the source and returns the gamepads connected and disconnected since the previous poll.
+func (p *GamepadPoller) Poll() (connected, disconnected []Gamepad) {
+	prev := map[int]Gamepad{}
+	for _, g := range p.current {
+		prev[g.Index] = g
+	}
+
+	var next []Gamepad
+	for _, g := range p.src.Gamepads() {
+		if !g.Connected {
+			continue
+		}
+		g.deadZone = p.DeadZone
+		if old, ok := prev[g.Index]; ok && old.ID == g.ID {
+			g.prev = old.Buttons
+			delete(prev, g.Index)
+		} else {
+			connected = append(connected, g)
+		}
+		next = append(next, g)
+	}
+	for _, g := range prev {
+		g.Connected = false
+		disconnected = append(disconnected, g)
+	}
+	sort.Slice(next, func(i, j int) bool { return next[i].Index < next[j].Index })
+	sort.Slice(disconnected, func(i, j int) bool { return disconnected[i].Index < disconnected[j].Index })
+
+	p.current = next
+	return connected, disconnected
+}
+
+// Gamepads returns the gamepads read by the last poll, ordered by index.
+func (p *GamepadPoller) Gamepads() []Gamepad {
+	return p.current
+}
+
+// Gamepad returns the gamepad with the given index from the last poll.
+func (p *GamepadPoller) Gamepad(index int) (Gamepad, bool) {
+	for _, g := range p.current {
+		if g.Index == index {
+			return g, true
+		}
+	}
+	return Gamepad{}, false
+}
@@ -0,0 +1,110 @@
+//go:build js && wasm
+
+package p5go
+
+import (
+	"syscall/js"
+)
+
+// GamepadHandler is a type for gamepad connection event handlers
+type GamepadHandler func(c *Canvas, g Gamepad)
+
+// GamepadConnected sets the handler called when a gamepad is connected.
+func GamepadConnected(handler GamepadHandler) Func {
+	return func(c *Canvas) {
+		c.gamepadFuncs["connected"] = handler
+		c.gamepadPoller()
+	}
+}
+
+// GamepadDisconnected sets the handler called when a gamepad is disconnected.
+func GamepadDisconnected(handler GamepadHandler) Func {
+	return func(c *Canvas) {
+		c.gamepadFuncs["disconnected"] = handler
+		c.gamepadPoller()
+	}
+}
+
+// Gamepads returns the connected gamepads.
+// The state is polled once per frame, so JustPressed and JustReleased report edges between frames.
+func (c *Canvas) Gamepads() []Gamepad {
+	if c.gamepads == nil {
+		// first use during a frame: poll now instead of waiting for the next one
+		c.gamepadPoller()
+		c.pollGamepads()
+	}
+	return c.gamepads.Gamepads()
+}
+
+// SetGamepadDeadZone sets the axis dead zone used by Gamepad.Axis and Gamepad.Stick.
+func (c *Canvas) SetGamepadDeadZone(deadZone float64) {
+	c.gamepadPoller().DeadZone = deadZone
+}
+
+// gamepadPoller returns the poller, registering it to poll before every frame on first use.
+func (c *Canvas) gamepadPoller() *GamepadPoller {
+	if c.gamepads != nil {
+		return c.gamepads
+	}
+	c.gamepads = NewGamepadPoller(GamepadSourceFunc(navigatorGamepads))
+	c.p5Instance.Call("registerMethod", "pre", js.FuncOf(func(value js.Value, args []js.Value) any {
+		c.pollGamepads()
+		return nil
+	}))
+	return c.gamepads
+}
+
+// pollGamepads polls the gamepads and calls the connection handlers.
+func (c *Canvas) pollGamepads() {
+	connected, disconnected := c.gamepads.Poll()
+	if h := c.gamepadFuncs["connected"]; h != nil {
+		for _, g := range connected {
+			h(c, g)
+		}
+	}
+	if h := c.gamepadFuncs["disconnected"]; h != nil {
+		for _, g := range disconnected {
+			h(c, g)
+		}
+	}
+}
+
+// navigatorGamepads reads navigator.getGamepads().
+func navigatorGamepads() []Gamepad {
+	nav := global.Get("navigator")
+	if nav.Get("getGamepads").Type() != js.TypeFunction {
+		return nil
+	}
+	pads := nav.Call("getGamepads")
+	var res []Gamepad
+	for i := 0; i < pads.Length(); i++ {
+		p := pads.Index(i)
+		if !p.Truthy() {
+			continue
+		}
+		g := Gamepad{
+			Index:     p.Get("index").Int(),
+			ID:        p.Get("id").String(),
+			Mapping:   p.Get("mapping").String(),
+			Connected: p.Get("connected").Truthy(),
+			Timestamp: p.Get("timestamp").Float(),
+		}
+		buttons := p.Get("buttons")
+		g.Buttons = make([]GamepadButton, buttons.Length())
+		for j := range g.Buttons {
+			b := buttons.Index(j)
+			g.Buttons[j] = GamepadButton{
+				Pressed: b.Get("pressed").Truthy(),
+				Touched: b.Get("touched").Truthy(),
+				Value:   b.Get("value").Float(),
+			}
+		}
+		axes := p.Get("axes")
+		g.Axes = make([]float64, axes.Length())
+		for j := range g.Axes {
+			g.Axes[j] = axes.Index(j).Float()
+		}
+		res = append(res, g)
+	}
+	return res
+}
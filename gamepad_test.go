@@ -0,0 +1,172 @@
+package p5go
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+// fakeGamepads is a GamepadSource returning whatever the test set last.
+type fakeGamepads struct {
+	pads []Gamepad
+}
+
+func (f *fakeGamepads) Gamepads() []Gamepad {
+	// copy the buttons as the browser source does, so the poller's previous state is not overwritten
+	res := make([]Gamepad, len(f.pads))
+	for i, g := range f.pads {
+		g.Buttons = append([]GamepadButton(nil), g.Buttons...)
+		g.Axes = append([]float64(nil), g.Axes...)
+		res[i] = g
+	}
+	return res
+}
+
+func pad(index int, id string, pressed ...int) Gamepad {
+	g := Gamepad{Index: index, ID: id, Mapping: GamepadMappingStandard, Connected: true, Buttons: make([]GamepadButton, 17), Axes: make([]float64, 4)}
+	for _, b := range pressed {
+		g.Buttons[b] = GamepadButton{Pressed: true, Value: 1}
+	}
+	return g
+}
+
+func indices(pads []Gamepad) []int {
+	res := make([]int, len(pads))
+	for i, g := range pads {
+		res[i] = g.Index
+	}
+	return res
+}
+
+func TestGamepadPollerConnections(t *testing.T) {
+	src := &fakeGamepads{}
+	p := NewGamepadPoller(src)
+
+	steps := []struct {
+		name                    string
+		pads                    []Gamepad
+		connected, disconnected []int
+		current                 []int
+	}{
+		{"none", nil, nil, nil, nil},
+		{"plug two", []Gamepad{pad(1, "b"), pad(0, "a")}, []int{1, 0}, nil, []int{0, 1}},
+		{"unchanged", []Gamepad{pad(0, "a"), pad(1, "b")}, nil, nil, []int{0, 1}},
+		{"unplug one", []Gamepad{pad(1, "b")}, nil, []int{0}, []int{1}},
+		{"reported disconnected", []Gamepad{{Index: 1, ID: "b"}}, nil, []int{1}, nil},
+		{"other pad in the same slot", []Gamepad{pad(0, "c")}, []int{0}, nil, []int{0}},
+		{"swap the pad in a slot", []Gamepad{pad(0, "d")}, []int{0}, []int{0}, []int{0}},
+	}
+	for _, s := range steps {
+		src.pads = s.pads
+		connected, disconnected := p.Poll()
+		if !slices.Equal(indices(connected), s.connected) {
+			t.Errorf("%s: connected = %v, want %v", s.name, indices(connected), s.connected)
+		}
+		if !slices.Equal(indices(disconnected), s.disconnected) {
+			t.Errorf("%s: disconnected = %v, want %v", s.name, indices(disconnected), s.disconnected)
+		}
+		for _, g := range disconnected {
+			if g.Connected {
+				t.Errorf("%s: disconnected gamepad %d reports Connected", s.name, g.Index)
+			}
+		}
+		if !slices.Equal(indices(p.Gamepads()), s.current) {
+			t.Errorf("%s: gamepads = %v, want %v", s.name, indices(p.Gamepads()), s.current)
+		}
+	}
+	if g, ok := p.Gamepad(0); !ok || g.ID != "d" {
+		t.Errorf("Gamepad(0) = %v, %v, want d", g.ID, ok)
+	}
+	if _, ok := p.Gamepad(1); ok {
+		t.Errorf("Gamepad(1) found after it was disconnected")
+	}
+}
+
+func TestGamepadButtonEdges(t *testing.T) {
+	src := &fakeGamepads{}
+	p := NewGamepadPoller(src)
+
+	steps := []struct {
+		pressed                               []int
+		pressedA, justPressedA, justReleasedA bool
+	}{
+		{nil, false, false, false},
+		{[]int{GamepadButtonA}, true, true, false},
+		{[]int{GamepadButtonA}, true, false, false},
+		{nil, false, false, true},
+		{nil, false, false, false},
+		{[]int{GamepadButtonA, GamepadButtonB}, true, true, false},
+	}
+	for i, s := range steps {
+		src.pads = []Gamepad{pad(0, "a", s.pressed...)}
+		p.Poll()
+		g, _ := p.Gamepad(0)
+		if got := g.Pressed(GamepadButtonA); got != s.pressedA {
+			t.Errorf("poll %d: Pressed = %v, want %v", i, got, s.pressedA)
+		}
+		if got := g.JustPressed(GamepadButtonA); got != s.justPressedA {
+			t.Errorf("poll %d: JustPressed = %v, want %v", i, got, s.justPressedA)
+		}
+		if got := g.JustReleased(GamepadButtonA); got != s.justReleasedA {
+			t.Errorf("poll %d: JustReleased = %v, want %v", i, got, s.justReleasedA)
+		}
+	}
+
+	// a newly connected pad holding a button reports it as just pressed
+	src.pads = []Gamepad{pad(0, "a"), pad(1, "b", GamepadButtonStart)}
+	p.Poll()
+	if g, _ := p.Gamepad(1); !g.JustPressed(GamepadButtonStart) {
+		t.Errorf("button held while connecting is not just pressed")
+	}
+	if g, _ := p.Gamepad(0); g.Pressed(99) || g.JustPressed(-1) || g.JustReleased(99) || g.Value(99) != 0 {
+		t.Errorf("out of range buttons report state")
+	}
+}
+
+func TestGamepadDeadZone(t *testing.T) {
+	src := &fakeGamepads{}
+	p := NewGamepadPoller(src)
+	p.DeadZone = 0.2
+
+	tests := []struct {
+		axis, want float64
+	}{
+		{0, 0},
+		{0.1, 0},
+		{-0.2, 0},
+		{0.6, 0.5},
+		{-0.6, -0.5},
+		{1, 1},
+		{-1.2, -1},
+	}
+	for _, tt := range tests {
+		g := pad(0, "a")
+		g.Axes[GamepadAxisLeftX] = tt.axis
+		src.pads = []Gamepad{g}
+		p.Poll()
+		got, _ := p.Gamepad(0)
+		if v := got.Axis(GamepadAxisLeftX); math.Abs(v-tt.want) > 1e-9 {
+			t.Errorf("Axis(%v) = %v, want %v", tt.axis, v, tt.want)
+		}
+	}
+
+	// the stick dead zone is radial: each axis is below the dead zone, the stick is not
+	g := pad(0, "a")
+	g.Axes[GamepadAxisLeftX], g.Axes[GamepadAxisLeftY] = 0.15, 0.15
+	g.Axes[GamepadAxisRightX], g.Axes[GamepadAxisRightY] = 0.1, 0.1
+	src.pads = []Gamepad{g}
+	p.Poll()
+	got, _ := p.Gamepad(0)
+	if got.Axis(GamepadAxisLeftX) != 0 {
+		t.Errorf("Axis inside the dead zone = %v, want 0", got.Axis(GamepadAxisLeftX))
+	}
+	if s := got.Stick(GamepadAxisLeftX, GamepadAxisLeftY); s.X <= 0 || s.Y <= 0 || math.Abs(s.X-s.Y) > 1e-12 {
+		t.Errorf("Stick outside the radial dead zone = %v, want a positive diagonal", s)
+	}
+	if s := got.Stick(GamepadAxisRightX, GamepadAxisRightY); s != (Vector{}) {
+		t.Errorf("Stick inside the dead zone = %v, want zero", s)
+	}
+	if s := got.Stick(GamepadAxisLeftX, 9); s != (Vector{}) {
+		t.Errorf("Stick with an unknown axis = %v, want zero", s)
+	}
+}
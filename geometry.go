@@ -0,0 +1,263 @@
+package p5go
+
+import (
+	"math"
+)
+
+// geometryEpsilon is the tolerance used for parallel lines and degenerate shapes.
+const geometryEpsilon = 1e-9
+
+// Min returns the top-left corner of the rectangle, for negative sizes as well.
+func (r Rectangle) Min() Vector {
+	return Vector{X: math.Min(r.Position.X, r.Position.X+r.Size.X), Y: math.Min(r.Position.Y, r.Position.Y+r.Size.Y)}
+}
+
+// Max returns the bottom-right corner of the rectangle, for negative sizes as well.
+func (r Rectangle) Max() Vector {
+	return Vector{X: math.Max(r.Position.X, r.Position.X+r.Size.X), Y: math.Max(r.Position.Y, r.Position.Y+r.Size.Y)}
+}
+
+// Center returns the center of the rectangle.
+func (r Rectangle) Center() Vector {
+	return r.Position.Add(r.Size.Div(2))
+}
+
+// Area returns the area of the rectangle.
+func (r Rectangle) Area() float64 {
+	return math.Abs(r.Size.X * r.Size.Y)
+}
+
+// Bounds returns the rectangle with a non-negative size.
+func (r Rectangle) Bounds() Rectangle {
+	lo, hi := r.Min(), r.Max()
+	return Rectangle{Position: lo, Size: hi.Sub(lo)}
+}
+
+// Contains returns true if p is inside the rectangle or on its edge.
+func (r Rectangle) Contains(p Vector) bool {
+	lo, hi := r.Min(), r.Max()
+	return p.X >= lo.X && p.X <= hi.X && p.Y >= lo.Y && p.Y <= hi.Y
+}
+
+// Intersects returns true if the rectangles overlap or touch.
+func (r Rectangle) Intersects(o Rectangle) bool {
+	_, ok := r.Intersection(o)
+	return ok
+}
+
+// Intersection returns the overlapping area of the rectangles.
+func (r Rectangle) Intersection(o Rectangle) (Rectangle, bool) {
+	amin, amax := r.Min(), r.Max()
+	bmin, bmax := o.Min(), o.Max()
+	lo := Vector{X: math.Max(amin.X, bmin.X), Y: math.Max(amin.Y, bmin.Y)}
+	hi := Vector{X: math.Min(amax.X, bmax.X), Y: math.Min(amax.Y, bmax.Y)}
+	if lo.X > hi.X || lo.Y > hi.Y {
+		return Rectangle{}, false
+	}
+	return Rectangle{Position: lo, Size: hi.Sub(lo)}, true
+}
+
+// Union returns the smallest rectangle containing both rectangles.
+func (r Rectangle) Union(o Rectangle) Rectangle {
+	amin, amax := r.Min(), r.Max()
+	bmin, bmax := o.Min(), o.Max()
+	lo := Vector{X: math.Min(amin.X, bmin.X), Y: math.Min(amin.Y, bmin.Y)}
+	hi := Vector{X: math.Max(amax.X, bmax.X), Y: math.Max(amax.Y, bmax.Y)}
+	return Rectangle{Position: lo, Size: hi.Sub(lo)}
+}
+
+// IntersectsCircle returns true if the rectangle and the circle overlap or touch.
+func (r Rectangle) IntersectsCircle(c Circle) bool {
+	return c.IntersectsRect(r)
+}
+
+// ClosestPoint returns the point of the rectangle closest to p.
+func (r Rectangle) ClosestPoint(p Vector) Vector {
+	lo, hi := r.Min(), r.Max()
+	return Vector{X: math.Max(lo.X, math.Min(p.X, hi.X)), Y: math.Max(lo.Y, math.Min(p.Y, hi.Y))}
+}
+
+// Radius returns the radius of the circle.
+func (c Circle) Radius() float64 {
+	return c.Diameter / 2
+}
+
+// Area returns the area of the circle.
+func (c Circle) Area() float64 {
+	return PI * c.Radius() * c.Radius()
+}
+
+// Bounds returns the bounding box of the circle.
+func (c Circle) Bounds() Rectangle {
+	r := c.Radius()
+	return Rectangle{Position: Vector{X: c.Position.X - r, Y: c.Position.Y - r}, Size: Vector{X: c.Diameter, Y: c.Diameter}}
+}
+
+// Contains returns true if p is inside the circle or on its edge.
+func (c Circle) Contains(p Vector) bool {
+	r := c.Radius()
+	return c.Position.Sub(p).MagSq() <= r*r
+}
+
+// Intersects returns true if the circles overlap or touch.
+func (c Circle) Intersects(o Circle) bool {
+	r := c.Radius() + o.Radius()
+	return c.Position.Sub(o.Position).MagSq() <= r*r
+}
+
+// IntersectsRect returns true if the circle and the rectangle overlap or touch.
+func (c Circle) IntersectsRect(r Rectangle) bool {
+	return c.Contains(r.ClosestPoint(c.Position))
+}
+
+// Length returns the length of the line.
+func (l Line) Length() float64 {
+	return l.Start.Dist(l.End)
+}
+
+// Midpoint returns the middle of the line.
+func (l Line) Midpoint() Vector {
+	return l.Start.Lerp(l.End, 0.5)
+}
+
+// Bounds returns the bounding box of the line.
+func (l Line) Bounds() Rectangle {
+	return BoundingBox([]Vector{l.Start, l.End})
+}
+
+// ClosestPoint returns the point of the line segment closest to p.
+func (l Line) ClosestPoint(p Vector) Vector {
+	d := l.End.Sub(l.Start)
+	lenSq := d.MagSq()
+	if lenSq < geometryEpsilon {
+		return l.Start
+	}
+	t := math.Max(0, math.Min(1, p.Sub(l.Start).Dot(d)/lenSq))
+	return l.Start.Add(d.Mult(t))
+}
+
+// Distance returns the distance from p to the line segment.
+func (l Line) Distance(p Vector) float64 {
+	return l.ClosestPoint(p).Dist(p)
+}
+
+// Intersects returns true if the line segments cross or touch.
+func (l Line) Intersects(o Line) bool {
+	_, ok := l.Intersection(o)
+	return ok
+}
+
+// Intersection returns the point where the line segments cross.
+// Parallel segments report no intersection, even when they overlap.
+func (l Line) Intersection(o Line) (Vector, bool) {
+	r := l.End.Sub(l.Start)
+	s := o.End.Sub(o.Start)
+	denom := r.Cross(s)
+	if math.Abs(denom) < geometryEpsilon {
+		return Vector{}, false
+	}
+	q := o.Start.Sub(l.Start)
+	t := q.Cross(s) / denom
+	u := q.Cross(r) / denom
+	if t < 0 || t > 1 || u < 0 || u > 1 {
+		return Vector{}, false
+	}
+	return l.Start.Add(r.Mult(t)), true
+}
+
+// IntersectsCircle returns true if the line segment touches the circle.
+func (l Line) IntersectsCircle(c Circle) bool {
+	return c.Contains(l.ClosestPoint(c.Position))
+}
+
+// Area returns the area of the triangle.
+func (t Triangle) Area() float64 {
+	return math.Abs(t.V2.Sub(t.V1).Cross(t.V3.Sub(t.V1))) / 2
+}
+
+// Centroid returns the center of mass of the triangle.
+func (t Triangle) Centroid() Vector {
+	return t.V1.Add(t.V2).Add(t.V3).Div(3)
+}
+
+// Bounds returns the bounding box of the triangle.
+func (t Triangle) Bounds() Rectangle {
+	return BoundingBox([]Vector{t.V1, t.V2, t.V3})
+}
+
+// Contains returns true if p is inside the triangle or on its edge.
+// A triangle whose vertices are on a line contains no point.
+func (t Triangle) Contains(p Vector) bool {
+	if math.Abs(t.V2.Sub(t.V1).Cross(t.V3.Sub(t.V1))) < geometryEpsilon {
+		return false
+	}
+	d1 := t.V2.Sub(t.V1).Cross(p.Sub(t.V1))
+	d2 := t.V3.Sub(t.V2).Cross(p.Sub(t.V2))
+	d3 := t.V1.Sub(t.V3).Cross(p.Sub(t.V3))
+	hasNeg := d1 < 0 || d2 < 0 || d3 < 0
+	hasPos := d1 > 0 || d2 > 0 || d3 > 0
+	return !(hasNeg && hasPos)
+}
+
+// BoundingBox returns the smallest rectangle containing the points.
+func BoundingBox(points []Vector) Rectangle {
+	if len(points) == 0 {
+		return Rectangle{}
+	}
+	lo, hi := points[0], points[0]
+	for _, p := range points[1:] {
+		lo = Vector{X: math.Min(lo.X, p.X), Y: math.Min(lo.Y, p.Y)}
+		hi = Vector{X: math.Max(hi.X, p.X), Y: math.Max(hi.Y, p.Y)}
+	}
+	return Rectangle{Position: lo, Size: hi.Sub(lo)}
+}
+
+// PolygonSignedArea returns the area of the polygon, positive when its vertices are clockwise
+// on screen (y down) and negative otherwise.
+func PolygonSignedArea(points []Vector) float64 {
+	var a float64
+	for i := range points {
+		a += points[i].Cross(points[(i+1)%len(points)])
+	}
+	return a / 2
+}
+
+// PolygonArea returns the area of the polygon.
+func PolygonArea(points []Vector) float64 {
+	return math.Abs(PolygonSignedArea(points))
+}
+
+// PolygonCentroid returns the center of mass of the polygon.
+func PolygonCentroid(points []Vector) Vector {
+	a := PolygonSignedArea(points)
+	if math.Abs(a) < geometryEpsilon {
+		// degenerate polygon: average of the vertices
+		var sum Vector
+		for _, p := range points {
+			sum = sum.Add(p)
+		}
+		if len(points) == 0 {
+			return sum
+		}
+		return sum.Div(float64(len(points)))
+	}
+	var c Vector
+	for i := range points {
+		p, q := points[i], points[(i+1)%len(points)]
+		f := p.Cross(q)
+		c = c.Add(p.Add(q).Mult(f))
+	}
+	return c.Div(6 * a)
+}
+
+// PointInPolygon returns true if p is inside the polygon, using the even-odd rule.
+func PointInPolygon(p Vector, points []Vector) bool {
+	in := false
+	for i, j := 0, len(points)-1; i < len(points); j, i = i, i+1 {
+		a, b := points[i], points[j]
+		if (a.Y > p.Y) != (b.Y > p.Y) && p.X < (b.X-a.X)*(p.Y-a.Y)/(b.Y-a.Y)+a.X {
+			in = !in
+		}
+	}
+	return in
+}
@@ -0,0 +1,231 @@
+package p5go
+
+import (
+	"testing"
+)
+
+func TestTriangleContains(t *testing.T) {
+	tri := Triangle{V1: Vector{X: 0, Y: 0}, V2: Vector{X: 10, Y: 0}, V3: Vector{X: 0, Y: 10}}
+	reversed := Triangle{V1: tri.V1, V2: tri.V3, V3: tri.V2}
+	collinear := Triangle{V1: Vector{X: 0, Y: 0}, V2: Vector{X: 5, Y: 5}, V3: Vector{X: 10, Y: 10}}
+	point := Triangle{V1: Vector{X: 3, Y: 3}, V2: Vector{X: 3, Y: 3}, V3: Vector{X: 3, Y: 3}}
+	tests := []struct {
+		name string
+		tri  Triangle
+		p    Vector
+		want bool
+	}{
+		{"inside", tri, Vector{X: 2, Y: 2}, true},
+		{"inside, reversed winding", reversed, Vector{X: 2, Y: 2}, true},
+		{"on an edge", tri, Vector{X: 5, Y: 0}, true},
+		{"on the hypotenuse", tri, Vector{X: 5, Y: 5}, true},
+		{"on a vertex", tri, Vector{X: 10, Y: 0}, true},
+		{"outside", tri, Vector{X: 6, Y: 6}, false},
+		{"outside, reversed winding", reversed, Vector{X: 6, Y: 6}, false},
+		{"beyond a vertex on an edge line", tri, Vector{X: 15, Y: 0}, false},
+		{"left of the triangle", tri, Vector{X: -1, Y: 5}, false},
+		{"collinear, on the line", collinear, Vector{X: 2, Y: 2}, false},
+		{"collinear, off the line", collinear, Vector{X: 2, Y: 3}, false},
+		{"collinear, at a vertex", collinear, Vector{X: 5, Y: 5}, false},
+		{"single point", point, Vector{X: 3, Y: 3}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tri.Contains(tt.p); got != tt.want {
+				t.Errorf("Contains(%v) = %v, want %v", tt.p, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRectangleCollisions(t *testing.T) {
+	rect := func(x, y, w, h float64) Rectangle {
+		return Rectangle{Position: Vector{X: x, Y: y}, Size: Vector{X: w, Y: h}}
+	}
+	tests := []struct {
+		name         string
+		a, b         Rectangle
+		intersects   bool
+		intersection Rectangle
+		union        Rectangle
+	}{
+		{"overlapping", rect(0, 0, 10, 10), rect(5, 5, 10, 10), true, rect(5, 5, 5, 5), rect(0, 0, 15, 15)},
+		{"contained", rect(0, 0, 10, 10), rect(2, 3, 4, 5), true, rect(2, 3, 4, 5), rect(0, 0, 10, 10)},
+		{"touching edges", rect(0, 0, 10, 10), rect(10, 0, 10, 10), true, rect(10, 0, 0, 10), rect(0, 0, 20, 10)},
+		{"touching corners", rect(0, 0, 10, 10), rect(10, 10, 5, 5), true, rect(10, 10, 0, 0), rect(0, 0, 15, 15)},
+		{"apart", rect(0, 0, 10, 10), rect(11, 0, 5, 5), false, Rectangle{}, rect(0, 0, 16, 10)},
+		{"negative size", rect(10, 10, -10, -10), rect(5, 5, 10, 10), true, rect(5, 5, 5, 5), rect(0, 0, 15, 15)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.Intersects(tt.b); got != tt.intersects {
+				t.Errorf("Intersects = %v, want %v", got, tt.intersects)
+			}
+			if got := tt.b.Intersects(tt.a); got != tt.intersects {
+				t.Errorf("reversed Intersects = %v, want %v", got, tt.intersects)
+			}
+			if got, _ := tt.a.Intersection(tt.b); got != tt.intersection {
+				t.Errorf("Intersection = %v, want %v", got, tt.intersection)
+			}
+			if got := tt.a.Union(tt.b); got != tt.union {
+				t.Errorf("Union = %v, want %v", got, tt.union)
+			}
+		})
+	}
+
+	r := rect(0, 0, 10, 10)
+	contains := []struct {
+		p    Vector
+		want bool
+	}{
+		{Vector{X: 5, Y: 5}, true},
+		{Vector{X: 0, Y: 0}, true},
+		{Vector{X: 10, Y: 5}, true},
+		{Vector{X: 10.1, Y: 5}, false},
+		{Vector{X: 5, Y: -0.1}, false},
+	}
+	for _, tt := range contains {
+		if got := r.Contains(tt.p); got != tt.want {
+			t.Errorf("Contains(%v) = %v, want %v", tt.p, got, tt.want)
+		}
+	}
+	if got := rect(10, 10, -10, -10).Contains(Vector{X: 5, Y: 5}); !got {
+		t.Errorf("rectangle with a negative size does not contain its center")
+	}
+}
+
+func TestCircleCollisions(t *testing.T) {
+	circle := func(x, y, d float64) Circle { return Circle{Position: Vector{X: x, Y: y}, Diameter: d} }
+	rect := Rectangle{Position: Vector{X: 0, Y: 0}, Size: Vector{X: 10, Y: 10}}
+	tests := []struct {
+		name        string
+		c           Circle
+		other       Circle
+		circles     bool
+		intersectsR bool
+	}{
+		{"overlapping", circle(0, 0, 10), circle(8, 0, 10), true, true},
+		{"touching", circle(-5, 5, 10), circle(5, 5, 10), true, true},
+		{"apart", circle(-6, 5, 10), circle(6, 5, 10), false, false},
+		{"inside the rect", circle(5, 5, 2), circle(5, 5, 20), true, true},
+		{"near a corner, outside", circle(-3, -3, 8), circle(20, 20, 2), false, false},
+		{"near a corner, touching", circle(-3, -4, 10), circle(20, 20, 2), false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.Intersects(tt.other); got != tt.circles {
+				t.Errorf("Intersects(%v) = %v, want %v", tt.other, got, tt.circles)
+			}
+			if got := tt.c.IntersectsRect(rect); got != tt.intersectsR {
+				t.Errorf("IntersectsRect = %v, want %v", got, tt.intersectsR)
+			}
+			if got := rect.IntersectsCircle(tt.c); got != tt.intersectsR {
+				t.Errorf("Rectangle.IntersectsCircle = %v, want %v", got, tt.intersectsR)
+			}
+		})
+	}
+}
+
+func TestLineCollisions(t *testing.T) {
+	line := func(x1, y1, x2, y2 float64) Line {
+		return Line{Start: Vector{X: x1, Y: y1}, End: Vector{X: x2, Y: y2}}
+	}
+	tests := []struct {
+		name string
+		a, b Line
+		ok   bool
+		want Vector
+	}{
+		{"crossing", line(0, 0, 10, 10), line(0, 10, 10, 0), true, Vector{X: 5, Y: 5}},
+		{"touching at an end", line(0, 0, 10, 0), line(10, -5, 10, 5), true, Vector{X: 10, Y: 0}},
+		{"sharing an end", line(0, 0, 10, 0), line(10, 0, 20, 10), true, Vector{X: 10, Y: 0}},
+		{"would cross if longer", line(0, 0, 4, 4), line(0, 10, 10, 0), false, Vector{}},
+		{"parallel", line(0, 0, 10, 0), line(0, 5, 10, 5), false, Vector{}},
+		{"collinear overlapping", line(0, 0, 10, 0), line(5, 0, 15, 0), false, Vector{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.a.Intersection(tt.b)
+			if ok != tt.ok || got.Dist(tt.want) > geometryEpsilon {
+				t.Errorf("Intersection = %v, %v, want %v, %v", got, ok, tt.want, tt.ok)
+			}
+			if tt.a.Intersects(tt.b) != tt.ok || tt.b.Intersects(tt.a) != tt.ok {
+				t.Errorf("Intersects is not %v both ways", tt.ok)
+			}
+		})
+	}
+
+	seg := line(0, 0, 10, 0)
+	closest := []struct {
+		p, want Vector
+	}{
+		{Vector{X: 5, Y: 3}, Vector{X: 5, Y: 0}},
+		{Vector{X: -4, Y: 3}, Vector{X: 0, Y: 0}},
+		{Vector{X: 14, Y: -3}, Vector{X: 10, Y: 0}},
+	}
+	for _, tt := range closest {
+		if got := seg.ClosestPoint(tt.p); got != tt.want {
+			t.Errorf("ClosestPoint(%v) = %v, want %v", tt.p, got, tt.want)
+		}
+	}
+	if d := seg.Distance(Vector{X: 13, Y: 4}); d != 5 {
+		t.Errorf("Distance past the end = %v, want 5", d)
+	}
+	if got := line(3, 3, 3, 3).ClosestPoint(Vector{X: 10, Y: 10}); got != (Vector{X: 3, Y: 3}) {
+		t.Errorf("ClosestPoint of a zero length line = %v, want its start", got)
+	}
+
+	circles := []struct {
+		c    Circle
+		want bool
+	}{
+		{Circle{Position: Vector{X: 5, Y: 3}, Diameter: 8}, true},
+		{Circle{Position: Vector{X: 5, Y: 4}, Diameter: 8}, true},
+		{Circle{Position: Vector{X: 5, Y: 5}, Diameter: 8}, false},
+		{Circle{Position: Vector{X: 13, Y: 0}, Diameter: 4}, false},
+	}
+	for _, tt := range circles {
+		if got := seg.IntersectsCircle(tt.c); got != tt.want {
+			t.Errorf("IntersectsCircle(%v) = %v, want %v", tt.c, got, tt.want)
+		}
+	}
+}
+
+func TestPolygonHelpers(t *testing.T) {
+	// an L shape, clockwise on screen
+	l := []Vector{{X: 0, Y: 0}, {X: 20, Y: 0}, {X: 20, Y: 10}, {X: 10, Y: 10}, {X: 10, Y: 20}, {X: 0, Y: 20}}
+	if a := PolygonSignedArea(l); a != 300 {
+		t.Errorf("signed area = %v, want 300", a)
+	}
+	reversed := make([]Vector, len(l))
+	for i, p := range l {
+		reversed[len(l)-1-i] = p
+	}
+	if a := PolygonSignedArea(reversed); a != -300 {
+		t.Errorf("signed area of the reversed polygon = %v, want -300", a)
+	}
+	if c := PolygonCentroid(l); c.Dist(Vector{X: 25.0 / 3, Y: 25.0 / 3}) > geometryEpsilon {
+		t.Errorf("centroid = %v, want (25/3, 25/3)", c)
+	}
+	if c := PolygonCentroid([]Vector{{X: 0, Y: 0}, {X: 2, Y: 2}, {X: 4, Y: 4}}); c != (Vector{X: 2, Y: 2}) {
+		t.Errorf("centroid of a degenerate polygon = %v, want the vertex average (2, 2)", c)
+	}
+	inside := []struct {
+		p    Vector
+		want bool
+	}{
+		{Vector{X: 5, Y: 5}, true},
+		{Vector{X: 15, Y: 5}, true},
+		{Vector{X: 5, Y: 15}, true},
+		{Vector{X: 15, Y: 15}, false},
+		{Vector{X: 25, Y: 5}, false},
+	}
+	for _, tt := range inside {
+		if got := PointInPolygon(tt.p, l); got != tt.want {
+			t.Errorf("PointInPolygon(%v) = %v, want %v", tt.p, got, tt.want)
+		}
+	}
+	if b := BoundingBox(l); b != (Rectangle{Size: Vector{X: 20, Y: 20}}) {
+		t.Errorf("BoundingBox = %v, want (0, 0, 20, 20)", b)
+	}
+}
@@ -0,0 +1,402 @@
+package p5go
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// GestureKind represents the kind of a recognized gesture
+type GestureKind string
+
+const (
+	// Gestures
+	GestureTap       GestureKind = "tap"
+	GestureDoubleTap GestureKind = "doubletap"
+	GestureLongPress GestureKind = "longpress"
+	GesturePan       GestureKind = "pan"
+	GesturePinch     GestureKind = "pinch"
+	GestureRotate    GestureKind = "rotate"
+)
+
+// GesturePhase represents the phase of a continuous gesture.
+// Discrete gestures (tap, double tap, long press) are reported with GestureEnded.
+type GesturePhase string
+
+const (
+	// Gesture phases
+	GestureBegan   GesturePhase = "began"
+	GestureChanged GesturePhase = "changed"
+	GestureEnded   GesturePhase = "ended"
+)
+
+// GestureEvent represents a recognized gesture
+type GestureEvent struct {
+	Kind        GestureKind
+	Phase       GesturePhase
+	Position    Vector  // location of the gesture, the centroid for multi-touch gestures
+	Delta       Vector  // pan movement since the previous event
+	Translation Vector  // pan movement since the gesture began
+	Scale       float64 // pinch scale relative to the start, 1 means unchanged
+	Rotation    float64 // rotation in radians since the gesture began
+	Pointers    int
+}
+
+// PointerPhase represents the phase of a pointer sample
+type PointerPhase string
+
+const (
+	// Pointer phases
+	PointerPhaseDown   PointerPhase = "down"
+	PointerPhaseMove   PointerPhase = "move"
+	PointerPhaseUp     PointerPhase = "up"
+	PointerPhaseCancel PointerPhase = "cancel"
+)
+
+// PointerSample is a single pointer event with its phase and timestamp, the input of a GestureRecognizer.
+type PointerSample struct {
+	Phase PointerPhase
+	Event PointerEvent
+	Time  time.Duration
+}
+
+// GestureConfig holds the thresholds used by a GestureRecognizer.
+// Zero fields are replaced with the values of DefaultGestureConfig.
+type GestureConfig struct {
+	TapTimeout        time.Duration // maximum press duration of a tap
+	TapSlop           float64       // maximum movement of a tap, also the pan threshold
+	DoubleTapInterval time.Duration // maximum time between the two taps of a double tap
+	DoubleTapSlop     float64       // maximum distance between the two taps of a double tap
+	LongPressDuration time.Duration // minimum press duration of a long press
+	PinchThreshold    float64       // minimum scale change before a pinch begins
+	RotateThreshold   float64       // minimum rotation in radians before a rotate begins
+}
+
+// DefaultGestureConfig returns the default gesture thresholds.
+func DefaultGestureConfig() GestureConfig {
+	return GestureConfig{
+		TapTimeout:        250 * time.Millisecond,
+		TapSlop:           10,
+		DoubleTapInterval: 300 * time.Millisecond,
+		DoubleTapSlop:     30,
+		LongPressDuration: 500 * time.Millisecond,
+		PinchThreshold:    0.05,
+		RotateThreshold:   0.1,
+	}
+}
+
+type trackedPointer struct {
+	id         int
+	start, pos Vector
+	down       time.Duration
+	order      int
+}
+
+// GestureRecognizer turns a stream of pointer samples into gestures.
+// It has no dependency on the browser, so it can be driven by synthetic samples.
+type GestureRecognizer struct {
+	cfg      GestureConfig
+	pointers map[int]*trackedPointer
+	order    int
+
+	// single pointer state
+	tapCandidate bool
+	longPressed  bool
+	lastTapTime  time.Duration
+	lastTapPos   Vector
+	hasLastTap   bool
+
+	// multi-touch state
+	multi        bool
+	startDist    float64
+	scaleBase    float64
+	scale        float64
+	prevAngle    float64
+	rotation     float64
+	startCenter  Vector
+	prevCenter   Vector
+	panning      bool
+	pinching     bool
+	rotating     bool
+	lastPointers int
+}
+
+// NewGestureRecognizer creates a GestureRecognizer with the given thresholds.
+func NewGestureRecognizer(cfg GestureConfig) *GestureRecognizer {
+	def := DefaultGestureConfig()
+	if cfg.TapTimeout == 0 {
+		cfg.TapTimeout = def.TapTimeout
+	}
+	if cfg.TapSlop == 0 {
+		cfg.TapSlop = def.TapSlop
+	}
+	if cfg.DoubleTapInterval == 0 {
+		cfg.DoubleTapInterval = def.DoubleTapInterval
+	}
+	if cfg.DoubleTapSlop == 0 {
+		cfg.DoubleTapSlop = def.DoubleTapSlop
+	}
+	if cfg.LongPressDuration == 0 {
+		cfg.LongPressDuration = def.LongPressDuration
+	}
+	if cfg.PinchThreshold == 0 {
+		cfg.PinchThreshold = def.PinchThreshold
+	}
+	if cfg.RotateThreshold == 0 {
+		cfg.RotateThreshold = def.RotateThreshold
+	}
+	return &GestureRecognizer{
+		cfg:      cfg,
+		pointers: map[int]*trackedPointer{},
+	}
+}
+
+// Feed processes a pointer sample and returns the gestures it completes or updates.
+func (r *GestureRecognizer) Feed(s PointerSample) []GestureEvent {
+	pos := Vector{X: s.Event.X, Y: s.Event.Y}
+	switch s.Phase {
+	case PointerPhaseDown:
+		return r.down(s.Event.ID, pos, s.Time)
+	case PointerPhaseMove:
+		return r.move(s.Event.ID, pos, s.Time)
+	case PointerPhaseUp:
+		return r.up(s.Event.ID, pos, s.Time)
+	case PointerPhaseCancel:
+		return r.cancel()
+	}
+	return nil
+}
+
+// Tick advances the recognizer clock so time based gestures such as long press can fire
+// while the pointers are not moving.
+func (r *GestureRecognizer) Tick(t time.Duration) []GestureEvent {
+	if len(r.pointers) != 1 || !r.tapCandidate || r.longPressed {
+		return nil
+	}
+	p := r.active()[0]
+	if t-p.down < r.cfg.LongPressDuration {
+		return nil
+	}
+	r.longPressed = true
+	r.tapCandidate = false
+	return []GestureEvent{{Kind: GestureLongPress, Phase: GestureEnded, Position: p.pos, Scale: 1, Pointers: 1}}
+}
+
+func (r *GestureRecognizer) down(id int, pos Vector, t time.Duration) []GestureEvent {
+	r.order++
+	r.pointers[id] = &trackedPointer{id: id, start: pos, pos: pos, down: t, order: r.order}
+
+	switch len(r.pointers) {
+	case 1:
+		r.tapCandidate = true
+		r.longPressed = false
+		r.multi = false
+		return nil
+	case 2:
+		// a second finger turns the interaction into a multi-touch gesture
+		events := r.endAll(pos)
+		r.tapCandidate = false
+		r.multi = true
+		r.scaleBase, r.scale, r.rotation = 1, 1, 0
+		r.beginMulti()
+		r.startCenter = r.prevCenter
+		return events
+	}
+	return nil
+}
+
+func (r *GestureRecognizer) move(id int, pos Vector, t time.Duration) []GestureEvent {
+	p, ok := r.pointers[id]
+	if !ok {
+		return nil
+	}
+	p.pos = pos
+
+	events := r.Tick(t)
+	if r.multi {
+		if len(r.pointers) >= 2 {
+			events = append(events, r.updateMulti()...)
+		}
+		return events
+	}
+	if len(r.pointers) != 1 {
+		return events
+	}
+	if p.start.Dist(pos) > r.cfg.TapSlop {
+		r.tapCandidate = false
+	}
+	if r.longPressed {
+		return events
+	}
+	if !r.panning {
+		if r.tapCandidate {
+			return events
+		}
+		r.panning = true
+		r.startCenter = p.start
+		r.prevCenter = p.start
+		events = append(events, r.panEvent(GestureBegan, p.start, 1))
+	}
+	return append(events, r.panEvent(GestureChanged, pos, 1))
+}
+
+func (r *GestureRecognizer) up(id int, pos Vector, t time.Duration) []GestureEvent {
+	p, ok := r.pointers[id]
+	if !ok {
+		return nil
+	}
+	p.pos = pos
+	n := len(r.pointers)
+	delete(r.pointers, id)
+
+	if r.multi {
+		var events []GestureEvent
+		if n == 2 {
+			events = r.endAll(r.prevCenter)
+		} else if len(r.pointers) >= 2 {
+			r.beginMulti()
+		}
+		if len(r.pointers) == 0 {
+			r.multi = false
+		}
+		return events
+	}
+
+	if r.panning {
+		return r.endAll(pos)
+	}
+	if !r.tapCandidate || r.longPressed || t-p.down > r.cfg.TapTimeout {
+		return nil
+	}
+	events := []GestureEvent{{Kind: GestureTap, Phase: GestureEnded, Position: pos, Scale: 1, Pointers: 1}}
+	if r.hasLastTap && t-r.lastTapTime <= r.cfg.DoubleTapInterval && r.lastTapPos.Dist(pos) <= r.cfg.DoubleTapSlop {
+		events = append(events, GestureEvent{Kind: GestureDoubleTap, Phase: GestureEnded, Position: pos, Scale: 1, Pointers: 1})
+		r.hasLastTap = false
+		return events
+	}
+	r.hasLastTap = true
+	r.lastTapTime = t
+	r.lastTapPos = pos
+	return events
+}
+
+func (r *GestureRecognizer) cancel() []GestureEvent {
+	events := r.endAll(r.prevCenter)
+	r.pointers = map[int]*trackedPointer{}
+	r.tapCandidate = false
+	r.multi = false
+	return events
+}
+
+// active returns the tracked pointers in the order they went down.
+func (r *GestureRecognizer) active() []*trackedPointer {
+	res := make([]*trackedPointer, 0, len(r.pointers))
+	for _, p := range r.pointers {
+		res = append(res, p)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].order < res[j].order })
+	return res
+}
+
+// beginMulti records the reference geometry of the first two pointers.
+// When the tracked pair changes mid-gesture, the accumulated scale and translation are kept
+// so the gesture continues without a jump.
+func (r *GestureRecognizer) beginMulti() {
+	ps := r.active()
+	a, b := ps[0].pos, ps[1].pos
+	center := a.Lerp(b, 0.5)
+	r.startDist = a.Dist(b)
+	r.scaleBase = r.scale
+	r.prevAngle = math.Atan2(b.Y-a.Y, b.X-a.X)
+	r.startCenter = r.startCenter.Add(center.Sub(r.prevCenter))
+	r.prevCenter = center
+}
+
+func (r *GestureRecognizer) updateMulti() []GestureEvent {
+	ps := r.active()
+	a, b := ps[0].pos, ps[1].pos
+	center := a.Lerp(b, 0.5)
+	n := len(ps)
+
+	scale := r.scaleBase
+	if r.startDist > 0 {
+		scale *= a.Dist(b) / r.startDist
+	}
+	r.scale = scale
+	angle := math.Atan2(b.Y-a.Y, b.X-a.X)
+	d := angle - r.prevAngle
+	for d > math.Pi {
+		d -= 2 * math.Pi
+	}
+	for d < -math.Pi {
+		d += 2 * math.Pi
+	}
+	r.rotation += d
+	r.prevAngle = angle
+
+	var events []GestureEvent
+	if !r.pinching && math.Abs(scale-1) > r.cfg.PinchThreshold {
+		r.pinching = true
+		events = append(events, GestureEvent{Kind: GesturePinch, Phase: GestureBegan, Position: center, Scale: 1, Pointers: n})
+	}
+	if r.pinching {
+		events = append(events, GestureEvent{Kind: GesturePinch, Phase: GestureChanged, Position: center, Scale: scale, Pointers: n})
+	}
+	if !r.rotating && math.Abs(r.rotation) > r.cfg.RotateThreshold {
+		r.rotating = true
+		events = append(events, GestureEvent{Kind: GestureRotate, Phase: GestureBegan, Position: center, Scale: 1, Pointers: n})
+	}
+	if r.rotating {
+		events = append(events, GestureEvent{Kind: GestureRotate, Phase: GestureChanged, Position: center, Scale: 1, Rotation: r.rotation, Pointers: n})
+	}
+	if !r.panning && r.startCenter.Dist(center) > r.cfg.TapSlop {
+		r.panning = true
+		events = append(events, r.panEvent(GestureBegan, r.startCenter, n))
+	}
+	if r.panning {
+		events = append(events, r.panEvent(GestureChanged, center, n))
+	}
+	r.lastPointers = n
+	return events
+}
+
+func (r *GestureRecognizer) panEvent(phase GesturePhase, pos Vector, n int) GestureEvent {
+	e := GestureEvent{
+		Kind:        GesturePan,
+		Phase:       phase,
+		Position:    pos,
+		Delta:       Vector{X: pos.X - r.prevCenter.X, Y: pos.Y - r.prevCenter.Y},
+		Translation: Vector{X: pos.X - r.startCenter.X, Y: pos.Y - r.startCenter.Y},
+		Scale:       1,
+		Pointers:    n,
+	}
+	r.prevCenter = pos
+	r.lastPointers = n
+	return e
+}
+
+// endAll ends every continuous gesture in progress.
+func (r *GestureRecognizer) endAll(pos Vector) []GestureEvent {
+	var events []GestureEvent
+	n := r.lastPointers
+	if r.pinching {
+		events = append(events, GestureEvent{Kind: GesturePinch, Phase: GestureEnded, Position: pos, Scale: r.scale, Pointers: n})
+		r.pinching = false
+	}
+	if r.rotating {
+		events = append(events, GestureEvent{Kind: GestureRotate, Phase: GestureEnded, Position: pos, Scale: 1, Rotation: r.rotation, Pointers: n})
+		r.rotating = false
+	}
+	if r.panning {
+		events = append(events, GestureEvent{
+			Kind:        GesturePan,
+			Phase:       GestureEnded,
+			Position:    r.prevCenter,
+			Translation: Vector{X: r.prevCenter.X - r.startCenter.X, Y: r.prevCenter.Y - r.startCenter.Y},
+			Scale:       1,
+			Pointers:    n,
+		})
+		r.panning = false
+	}
+	return events
+}
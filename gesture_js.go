@@ -0,0 +1,64 @@
+//go:build js && wasm
+
+package p5go
+
+import (
+	"syscall/js"
+	"time"
+)
+
+// GestureHandler is a type for gesture event handlers
+type GestureHandler func(c *Canvas, e GestureEvent)
+
+// Gestures sets the gesture handler for the canvas.
+// Pointer events on the canvas are fed to a GestureRecognizer configured with cfg, or the defaults.
+func Gestures(handler GestureHandler, cfg ...GestureConfig) Func {
+	return func(c *Canvas) {
+		var conf GestureConfig
+		if len(cfg) > 0 {
+			conf = cfg[0]
+		}
+		r := NewGestureRecognizer(conf)
+		emit := func(events []GestureEvent) {
+			for _, e := range events {
+				handler(c, e)
+			}
+		}
+
+		for event, phase := range map[string]PointerPhase{
+			"pointerdown":   PointerPhaseDown,
+			"pointermove":   PointerPhaseMove,
+			"pointerup":     PointerPhaseUp,
+			"pointercancel": PointerPhaseCancel,
+		} {
+			phase := phase
+			c.handlePointer(event, func(e InputEvent) any {
+				emit(r.Feed(PointerSample{
+					Phase: phase,
+					Event: *e.Pointer,
+					Time:  millis(e.State.Time),
+				}))
+				return nil
+			})
+		}
+
+		// long press must fire while the pointer stays still, so the clock also advances every frame
+		c.p5Instance.Call("registerMethod", "pre", js.FuncOf(func(value js.Value, args []js.Value) any {
+			emit(r.Tick(c.now()))
+			return nil
+		}))
+	}
+}
+
+// millis converts milliseconds on the performance.now clock to a Duration.
+func millis(ms float64) time.Duration {
+	return time.Duration(ms * float64(time.Millisecond))
+}
+
+// now returns the current time of the performance.now clock, or the recorded time while replaying.
+func (c *Canvas) now() time.Duration {
+	if s := c.replayState; s != nil {
+		return millis(s.Time)
+	}
+	return millis(global.Get("performance").Call("now").Float())
+}
@@ -0,0 +1,257 @@
+package p5go
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+)
+
+func sample(phase PointerPhase, id int, x, y float64, ms int) PointerSample {
+	return PointerSample{
+		Phase: phase,
+		Event: PointerEvent{ID: id, Type: PointerTouch, X: x, Y: y},
+		Time:  time.Duration(ms) * time.Millisecond,
+	}
+}
+
+func feed(r *GestureRecognizer, samples ...PointerSample) []GestureEvent {
+	var events []GestureEvent
+	for _, s := range samples {
+		events = append(events, r.Feed(s)...)
+	}
+	return events
+}
+
+// kinds returns the kind and phase of every event, such as "pan/began".
+func kinds(events []GestureEvent) []string {
+	res := make([]string, len(events))
+	for i, e := range events {
+		res[i] = string(e.Kind) + "/" + string(e.Phase)
+	}
+	return res
+}
+
+func find(events []GestureEvent, kind GestureKind, phase GesturePhase) (GestureEvent, bool) {
+	var found GestureEvent
+	ok := false
+	for _, e := range events {
+		if e.Kind == kind && e.Phase == phase {
+			found, ok = e, true
+		}
+	}
+	return found, ok
+}
+
+func TestGestureTap(t *testing.T) {
+	r := NewGestureRecognizer(GestureConfig{})
+	events := feed(r,
+		sample(PointerPhaseDown, 1, 100, 100, 0),
+		sample(PointerPhaseMove, 1, 103, 101, 50),
+		sample(PointerPhaseUp, 1, 103, 101, 100),
+	)
+	if want := []string{"tap/ended"}; !slices.Equal(kinds(events), want) {
+		t.Fatalf("events = %v, want %v", kinds(events), want)
+	}
+	if p := events[0].Position; p != (Vector{X: 103, Y: 101}) {
+		t.Errorf("tap position = %v", p)
+	}
+}
+
+func TestGestureTapTooSlow(t *testing.T) {
+	r := NewGestureRecognizer(GestureConfig{})
+	events := feed(r,
+		sample(PointerPhaseDown, 1, 100, 100, 0),
+		sample(PointerPhaseUp, 1, 100, 100, 400),
+	)
+	if len(events) != 0 {
+		t.Fatalf("events = %v, want none", kinds(events))
+	}
+}
+
+func TestGestureDoubleTap(t *testing.T) {
+	tests := []struct {
+		name      string
+		second    PointerSample
+		secondUp  PointerSample
+		doubleTap bool
+	}{
+		{"close", sample(PointerPhaseDown, 2, 105, 100, 200), sample(PointerPhaseUp, 2, 105, 100, 250), true},
+		{"too late", sample(PointerPhaseDown, 2, 105, 100, 500), sample(PointerPhaseUp, 2, 105, 100, 550), false},
+		{"too far", sample(PointerPhaseDown, 2, 200, 100, 200), sample(PointerPhaseUp, 2, 200, 100, 250), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewGestureRecognizer(GestureConfig{})
+			events := feed(r,
+				sample(PointerPhaseDown, 1, 100, 100, 0),
+				sample(PointerPhaseUp, 1, 100, 100, 50),
+				tt.second,
+				tt.secondUp,
+			)
+			want := []string{"tap/ended", "tap/ended"}
+			if tt.doubleTap {
+				want = append(want, "doubletap/ended")
+			}
+			if !slices.Equal(kinds(events), want) {
+				t.Fatalf("events = %v, want %v", kinds(events), want)
+			}
+		})
+	}
+}
+
+func TestGestureLongPress(t *testing.T) {
+	r := NewGestureRecognizer(GestureConfig{})
+	feed(r, sample(PointerPhaseDown, 1, 50, 60, 0))
+	if events := r.Tick(400 * time.Millisecond); len(events) != 0 {
+		t.Fatalf("long press before its duration: %v", kinds(events))
+	}
+	events := r.Tick(600 * time.Millisecond)
+	if want := []string{"longpress/ended"}; !slices.Equal(kinds(events), want) {
+		t.Fatalf("events = %v, want %v", kinds(events), want)
+	}
+	if events := r.Tick(700 * time.Millisecond); len(events) != 0 {
+		t.Fatalf("long press fired twice: %v", kinds(events))
+	}
+	// lifting the finger after a long press is not a tap
+	if events := feed(r, sample(PointerPhaseUp, 1, 50, 60, 800)); len(events) != 0 {
+		t.Fatalf("events after long press = %v, want none", kinds(events))
+	}
+}
+
+func TestGesturePan(t *testing.T) {
+	r := NewGestureRecognizer(GestureConfig{})
+	events := feed(r,
+		sample(PointerPhaseDown, 1, 0, 0, 0),
+		sample(PointerPhaseMove, 1, 5, 0, 20),
+		sample(PointerPhaseMove, 1, 20, 0, 40),
+		sample(PointerPhaseMove, 1, 30, 10, 60),
+		sample(PointerPhaseUp, 1, 30, 10, 80),
+	)
+	want := []string{"pan/began", "pan/changed", "pan/changed", "pan/ended"}
+	if !slices.Equal(kinds(events), want) {
+		t.Fatalf("events = %v, want %v", kinds(events), want)
+	}
+	if d := events[2].Delta; d != (Vector{X: 10, Y: 10}) {
+		t.Errorf("delta = %v, want {10 10}", d)
+	}
+	if tr := events[3].Translation; tr != (Vector{X: 30, Y: 10}) {
+		t.Errorf("translation = %v, want {30 10}", tr)
+	}
+}
+
+func TestGesturePinch(t *testing.T) {
+	r := NewGestureRecognizer(GestureConfig{})
+	events := feed(r,
+		sample(PointerPhaseDown, 1, 90, 100, 0),
+		sample(PointerPhaseDown, 2, 110, 100, 10),
+		sample(PointerPhaseMove, 1, 80, 100, 30),
+		sample(PointerPhaseMove, 2, 120, 100, 30),
+		sample(PointerPhaseUp, 2, 120, 100, 60),
+		sample(PointerPhaseUp, 1, 80, 100, 60),
+	)
+	if _, ok := find(events, GesturePinch, GestureBegan); !ok {
+		t.Fatalf("no pinch began in %v", kinds(events))
+	}
+	end, ok := find(events, GesturePinch, GestureEnded)
+	if !ok {
+		t.Fatalf("no pinch ended in %v", kinds(events))
+	}
+	if math.Abs(end.Scale-2) > 1e-9 {
+		t.Errorf("scale = %v, want 2", end.Scale)
+	}
+	if _, ok := find(events, GestureRotate, GestureBegan); ok {
+		t.Errorf("pinch along a line also rotated: %v", kinds(events))
+	}
+	if _, ok := find(events, GesturePan, GestureBegan); ok {
+		t.Errorf("pinch around a fixed center also panned: %v", kinds(events))
+	}
+	if _, ok := find(events, GestureTap, GestureEnded); ok {
+		t.Errorf("pinch also tapped: %v", kinds(events))
+	}
+}
+
+func TestGestureRotate(t *testing.T) {
+	r := NewGestureRecognizer(GestureConfig{})
+	events := feed(r,
+		sample(PointerPhaseDown, 1, 90, 100, 0),
+		sample(PointerPhaseDown, 2, 110, 100, 10),
+	)
+	// turn the pair a quarter turn clockwise around its center in small steps, one finger at a time
+	const steps = 40
+	for i := 1; i <= steps; i++ {
+		a := math.Pi / 2 * float64(i) / steps
+		x, y := 10*math.Cos(a), 10*math.Sin(a)
+		events = append(events, feed(r,
+			sample(PointerPhaseMove, 1, 100-x, 100-y, 10+i),
+			sample(PointerPhaseMove, 2, 100+x, 100+y, 10+i),
+		)...)
+	}
+	events = append(events, feed(r,
+		sample(PointerPhaseUp, 1, 100, 90, 60),
+		sample(PointerPhaseUp, 2, 100, 110, 60),
+	)...)
+	end, ok := find(events, GestureRotate, GestureEnded)
+	if !ok {
+		t.Fatalf("no rotate ended in %v", kinds(events))
+	}
+	if math.Abs(end.Rotation-math.Pi/2) > 1e-9 {
+		t.Errorf("rotation = %v, want pi/2", end.Rotation)
+	}
+	if _, ok := find(events, GesturePinch, GestureBegan); ok {
+		t.Errorf("rotation at a fixed distance also pinched: %v", kinds(events))
+	}
+}
+
+func TestGestureTwoFingerPan(t *testing.T) {
+	r := NewGestureRecognizer(GestureConfig{})
+	events := feed(r,
+		sample(PointerPhaseDown, 1, 90, 100, 0),
+		sample(PointerPhaseDown, 2, 110, 100, 10),
+		sample(PointerPhaseMove, 1, 90, 150, 30),
+		sample(PointerPhaseMove, 2, 110, 150, 30),
+		sample(PointerPhaseUp, 1, 90, 150, 60),
+	)
+	end, ok := find(events, GesturePan, GestureEnded)
+	if !ok {
+		t.Fatalf("no pan ended in %v", kinds(events))
+	}
+	if end.Translation != (Vector{X: 0, Y: 50}) || end.Pointers != 2 {
+		t.Errorf("pan end = %+v, want translation {0 50} with 2 pointers", end)
+	}
+}
+
+func TestGestureCancel(t *testing.T) {
+	r := NewGestureRecognizer(GestureConfig{})
+	events := feed(r,
+		sample(PointerPhaseDown, 1, 90, 100, 0),
+		sample(PointerPhaseDown, 2, 110, 100, 10),
+		sample(PointerPhaseMove, 1, 80, 100, 30),
+		sample(PointerPhaseMove, 2, 120, 100, 30),
+		sample(PointerPhaseCancel, 1, 80, 100, 40),
+	)
+	if _, ok := find(events, GesturePinch, GestureEnded); !ok {
+		t.Fatalf("cancel did not end the pinch: %v", kinds(events))
+	}
+	// the cancelled pointers are forgotten: their late up events do nothing, and a new press is a tap
+	events = feed(r,
+		sample(PointerPhaseUp, 2, 120, 100, 50),
+		sample(PointerPhaseDown, 3, 10, 10, 1000),
+		sample(PointerPhaseUp, 3, 10, 10, 1050),
+	)
+	if want := []string{"tap/ended"}; !slices.Equal(kinds(events), want) {
+		t.Fatalf("events after cancel = %v, want %v", kinds(events), want)
+	}
+
+	// cancelling a single press neither taps nor long presses
+	r = NewGestureRecognizer(GestureConfig{})
+	events = feed(r,
+		sample(PointerPhaseDown, 1, 0, 0, 0),
+		sample(PointerPhaseCancel, 1, 0, 0, 50),
+		sample(PointerPhaseUp, 1, 0, 0, 60),
+	)
+	events = append(events, r.Tick(time.Second)...)
+	if len(events) != 0 {
+		t.Fatalf("events after cancelled press = %v, want none", kinds(events))
+	}
+}
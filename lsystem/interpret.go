@@ -0,0 +1,67 @@
+package lsystem
+
+import (
+	"github.com/ryomak/p5go"
+	"github.com/ryomak/p5go/turtle"
+)
+
+// Command draws a module with the turtle.
+type Command func(t *turtle.Turtle, params []float64)
+
+// Interpreter maps symbols to turtle commands.
+type Interpreter struct {
+	Step     float64 // default distance of F, G and f
+	Angle    float64 // default angle of + and -, in radians
+	Commands map[rune]Command
+}
+
+// NewInterpreter creates an interpreter with the usual commands. A first parameter replaces the
+// default distance or angle:
+//
+//	F, G  move forward drawing a line
+//	f     move forward without drawing
+//	+     turn left (counter-clockwise on screen)
+//	-     turn right
+//	|     turn around
+//	[     push the turtle state
+//	]     pop the turtle state
+//
+// Other symbols are ignored unless a command is added for them.
+func NewInterpreter(step, angle float64) *Interpreter {
+	in := &Interpreter{Step: step, Angle: angle}
+	forward := func(t *turtle.Turtle, p []float64) { t.Forward(param(p, in.Step)) }
+	in.Commands = map[rune]Command{
+		'F': forward,
+		'G': forward,
+		'f': func(t *turtle.Turtle, p []float64) {
+			pen := t.Pen
+			t.PenUp()
+			t.Forward(param(p, in.Step))
+			if pen {
+				t.PenDown()
+			}
+		},
+		'+': func(t *turtle.Turtle, p []float64) { t.Left(param(p, in.Angle)) },
+		'-': func(t *turtle.Turtle, p []float64) { t.Right(param(p, in.Angle)) },
+		'|': func(t *turtle.Turtle, p []float64) { t.Turn(p5go.PI) },
+		'[': func(t *turtle.Turtle, p []float64) { t.PushState() },
+		']': func(t *turtle.Turtle, p []float64) { t.PopState() },
+	}
+	return in
+}
+
+// Run draws the modules with the turtle.
+func (in *Interpreter) Run(t *turtle.Turtle, modules []Module) {
+	for _, m := range modules {
+		if cmd := in.Commands[m.Symbol]; cmd != nil {
+			cmd(t, m.Params)
+		}
+	}
+}
+
+func param(params []float64, def float64) float64 {
+	if len(params) > 0 {
+		return params[0]
+	}
+	return def
+}
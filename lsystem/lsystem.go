@@ -0,0 +1,162 @@
+// Package lsystem rewrites strings of modules with L-system rules and draws them with a turtle.
+package lsystem
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// Module is a symbol with optional numeric parameters, written F or F(1,2.5).
+type Module struct {
+	Symbol rune
+	Params []float64
+}
+
+// String returns the module in the notation read by Parse.
+func (m Module) String() string {
+	if len(m.Params) == 0 {
+		return string(m.Symbol)
+	}
+	params := make([]string, len(m.Params))
+	for i, p := range m.Params {
+		params[i] = strconv.FormatFloat(p, 'g', -1, 64)
+	}
+	return string(m.Symbol) + "(" + strings.Join(params, ",") + ")"
+}
+
+// Parse reads a string of modules such as "F(1)[+F]F". Spaces are ignored and parameters that
+// are not numbers are read as 0.
+func Parse(s string) []Module {
+	var out []Module
+	rs := []rune(s)
+	for i := 0; i < len(rs); i++ {
+		if rs[i] == ' ' || rs[i] == '\t' || rs[i] == '\n' {
+			continue
+		}
+		m := Module{Symbol: rs[i]}
+		if i+1 < len(rs) && rs[i+1] == '(' {
+			end := i + 2
+			for end < len(rs) && rs[end] != ')' {
+				end++
+			}
+			for _, p := range strings.Split(string(rs[i+2:min(end, len(rs))]), ",") {
+				v, _ := strconv.ParseFloat(strings.TrimSpace(p), 64)
+				m.Params = append(m.Params, v)
+			}
+			i = end
+		}
+		out = append(out, m)
+	}
+	return out
+}
+
+// Format returns the modules in the notation read by Parse.
+func Format(modules []Module) string {
+	var b strings.Builder
+	for _, m := range modules {
+		b.WriteString(m.String())
+	}
+	return b.String()
+}
+
+// Rule rewrites a module. Among the rules matching a module one is chosen at random in
+// proportion to Weight, which makes the system stochastic.
+type Rule struct {
+	Symbol rune
+	// Weight is the relative probability of the rule, 1 if zero.
+	Weight float64
+	// Condition restricts the rule to modules whose parameters satisfy it. It may be nil.
+	Condition func(params []float64) bool
+	// Successor replaces the module, in the notation read by Parse.
+	Successor string
+	// Produce computes the replacement from the parameters of the module, for parametric rules.
+	// It takes precedence over Successor.
+	Produce func(params []float64) []Module
+
+	successor []Module
+	parsed    bool
+}
+
+// LSystem is an axiom and the rules applied to it at every generation.
+type LSystem struct {
+	Axiom []Module
+	Rules []*Rule
+}
+
+// New creates an L-system from an axiom in the notation read by Parse.
+func New(axiom string, rules ...Rule) *LSystem {
+	l := &LSystem{Axiom: Parse(axiom)}
+	for i := range rules {
+		l.Rules = append(l.Rules, &rules[i])
+	}
+	return l
+}
+
+// Generate applies the rules n times to the axiom. Modules without a matching rule are kept.
+// rng chooses between stochastic rules; it may be nil if there are none, in which case the first
+// matching rule is always used.
+func (l *LSystem) Generate(rng *rand.Rand, n int) []Module {
+	cur := l.Axiom
+	for i := 0; i < n; i++ {
+		cur = l.Step(rng, cur)
+	}
+	return cur
+}
+
+// Step applies the rules once to modules.
+func (l *LSystem) Step(rng *rand.Rand, modules []Module) []Module {
+	out := make([]Module, 0, len(modules)*2)
+	var matches []*Rule
+	for _, m := range modules {
+		matches = matches[:0]
+		total := 0.0
+		for _, r := range l.Rules {
+			if r.Symbol == m.Symbol && (r.Condition == nil || r.Condition(m.Params)) {
+				matches = append(matches, r)
+				total += r.weight()
+			}
+		}
+		if len(matches) == 0 {
+			out = append(out, m)
+			continue
+		}
+		r := matches[0]
+		if rng != nil && len(matches) > 1 {
+			pick := rng.Float64() * total
+			for _, c := range matches {
+				if pick -= c.weight(); pick < 0 {
+					r = c
+					break
+				}
+			}
+		}
+		// copy the parameters, so the produced modules share them neither with the parsed successor
+		// nor with each other
+		for _, pm := range r.produce(m.Params) {
+			if pm.Params != nil {
+				pm.Params = append([]float64(nil), pm.Params...)
+			}
+			out = append(out, pm)
+		}
+	}
+	return out
+}
+
+func (r *Rule) weight() float64 {
+	if r.Weight <= 0 {
+		return 1
+	}
+	return r.Weight
+}
+
+func (r *Rule) produce(params []float64) []Module {
+	if r.Produce != nil {
+		return r.Produce(params)
+	}
+	if !r.parsed {
+		r.successor = Parse(r.Successor)
+		r.parsed = true
+	}
+	return r.successor
+}
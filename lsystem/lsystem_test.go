@@ -0,0 +1,121 @@
+package lsystem
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/ryomak/p5go/turtle"
+)
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"F+F", "F+F"},
+		{"F(1) [ +F(2.5,3) ]", "F(1)[+F(2.5,3)]"},
+		{"A(x)", "A(0)"},
+		{"A(1", "A(1)"},
+	}
+	for _, tt := range tests {
+		if got := Format(Parse(tt.in)); got != tt.want {
+			t.Errorf("Format(Parse(%q)) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name string
+		l    *LSystem
+		n    int
+		want string
+	}{
+		{"algae", New("A", Rule{Symbol: 'A', Successor: "AB"}, Rule{Symbol: 'B', Successor: "A"}), 4, "ABAABABA"},
+		{"koch", New("F", Rule{Symbol: 'F', Successor: "F+F-F-F+F"}), 1, "F+F-F-F+F"},
+		{"no generations", New("F", Rule{Symbol: 'F', Successor: "FF"}), 0, "F"},
+		{"unmatched modules are kept", New("X+Y", Rule{Symbol: 'X', Successor: "XX"}), 2, "XXXX+Y"},
+	}
+	for _, tt := range tests {
+		if got := Format(tt.l.Generate(nil, tt.n)); got != tt.want {
+			t.Errorf("%s: Generate(%d) = %q, want %q", tt.name, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestParametricRules(t *testing.T) {
+	// each segment splits in two of half the length until it is shorter than 1
+	l := New("F(8)",
+		Rule{
+			Symbol:    'F',
+			Condition: func(p []float64) bool { return p[0] >= 1 },
+			Produce: func(p []float64) []Module {
+				return []Module{{Symbol: 'F', Params: []float64{p[0] / 2}}, {Symbol: 'F', Params: []float64{p[0] / 2}}}
+			},
+		},
+		Rule{Symbol: 'F', Condition: func(p []float64) bool { return p[0] < 1 }, Successor: "X"},
+	)
+	if got, want := Format(l.Generate(nil, 3)), strings.Repeat("F(1)", 8); got != want {
+		t.Errorf("Generate(3) = %q, want %q", got, want)
+	}
+	if got, want := Format(l.Generate(nil, 5)), strings.Repeat("X", 16); got != want {
+		t.Errorf("Generate(5) = %q, want %q", got, want)
+	}
+}
+
+func TestProducedParamsAreCopied(t *testing.T) {
+	l := New("A", Rule{Symbol: 'A', Successor: "B(1)B(1)"}, Rule{
+		Symbol:  'C',
+		Produce: func(p []float64) []Module { return []Module{{Symbol: 'C', Params: p}, {Symbol: 'C', Params: p}} },
+	})
+	out := l.Generate(nil, 1)
+	out[0].Params[0] = 5
+	if out[1].Params[0] != 1 {
+		t.Errorf("modules produced by one rule share their parameters")
+	}
+	if again := l.Generate(nil, 1); again[0].Params[0] != 1 {
+		t.Errorf("changing a produced module changed the rule successor")
+	}
+
+	in := []Module{{Symbol: 'C', Params: []float64{2}}}
+	out = l.Step(nil, in)
+	out[0].Params[0] = 3
+	if out[1].Params[0] != 2 || in[0].Params[0] != 2 {
+		t.Errorf("modules produced from the same parameters share them")
+	}
+}
+
+func TestStochasticRules(t *testing.T) {
+	l := New("F", Rule{Symbol: 'F', Weight: 3, Successor: "A"}, Rule{Symbol: 'F', Weight: 1, Successor: "B"})
+	if got := Format(l.Generate(nil, 1)); got != "A" {
+		t.Errorf("Generate without rng = %q, want the first rule", got)
+	}
+	rng := rand.New(rand.NewSource(1))
+	counts := map[string]int{}
+	for i := 0; i < 4000; i++ {
+		counts[Format(l.Generate(rng, 1))]++
+	}
+	if ratio := float64(counts["A"]) / float64(counts["B"]); math.Abs(ratio-3) > 0.4 {
+		t.Errorf("rules chosen %v, want about 3 A for every B", counts)
+	}
+}
+
+func TestInterpreter(t *testing.T) {
+	tu := turtle.New(nil)
+	in := NewInterpreter(10, math.Pi/2)
+	in.Run(tu, Parse("F[+F(5)]fF-F"))
+
+	paths := tu.Paths()
+	// the branch continues the first line, and the jump of f starts a new one
+	if len(paths) != 2 || len(paths[0]) != 3 || len(paths[1]) != 3 {
+		t.Fatalf("paths = %v, want two lines of three points", paths)
+	}
+	// + turns counter-clockwise on screen, which is up with y pointing down
+	if end := paths[0][2]; math.Abs(end.X-10) > 1e-9 || math.Abs(end.Y+5) > 1e-9 {
+		t.Errorf("branch ends at %v, want (10, -5)", end)
+	}
+	if end := tu.Position; math.Abs(end.X-30) > 1e-9 || math.Abs(end.Y-10) > 1e-9 {
+		t.Errorf("turtle ends at %v, want (30, 10)", end)
+	}
+}
@@ -1,172 +1,14 @@
-// Package p5go provides a bridge between Go and p5.js.
+//go:build js && wasm
+
 package p5go
 
 import (
 	"errors"
 	"fmt"
-	"math"
+	"math/rand"
+	"strings"
 	"syscall/js"
-)
-
-// RendererMode represents the rendering mode for the canvas
-type RendererMode string
-
-const (
-	// Renderer modes
-	P2D   RendererMode = "p2d"
-	WEBGL RendererMode = "webgl"
-)
-
-// CursorStyle represents the cursor style
-type CursorStyle string
-
-const (
-	// Environment
-	ARROW CursorStyle = "default"
-	CROSS CursorStyle = "crosshair"
-	HAND  CursorStyle = "pointer"
-	MOVE  CursorStyle = "move"
-	TEXT  CursorStyle = "text"
-	WAIT  CursorStyle = "wait"
-)
-
-// AngleMode represents the angle mode
-type AngleMode string
-
-const (
-	// Trigonometry
-	PI                   = math.Pi
-	HALF_PI              = math.Pi / 2
-	QUARTER_PI           = math.Pi / 4
-	TWO_PI               = math.Pi * 2
-	TAU                  = TWO_PI
-	DEGREES    AngleMode = "degrees"
-	RADIANS    AngleMode = "radians"
-)
-
-// ColorMode represents the color mode
-type ColorMode string
-
-const (
-	// Color modes
-	RGB ColorMode = "rgb"
-	HSB ColorMode = "hsb"
-	HSL ColorMode = "hsl"
-)
-
-// DrawingMode represents the drawing mode
-type DrawingMode string
-
-const (
-	// Drawing modes
-	CORNER   DrawingMode = "corner"
-	CORNERS  DrawingMode = "corners"
-	RADIUS   DrawingMode = "radius"
-	RIGHT    DrawingMode = "right"
-	LEFT     DrawingMode = "left"
-	CENTER   DrawingMode = "center"
-	TOP      DrawingMode = "top"
-	BOTTOM   DrawingMode = "bottom"
-	BASELINE DrawingMode = "alphabetic"
-)
-
-// ShapeType represents the type of shape
-// P5.jsの beginShape で使う型
-// https://p5js.org/reference/#/p5/beginShape
-// "POINTS", "LINES", "TRIANGLES", "TRIANGLE_FAN", "TRIANGLE_STRIP", "QUADS", "QUAD_STRIP", "TESS"
-type ShapeType string
-
-const (
-	POINTS         ShapeType = "POINTS"
-	LINES          ShapeType = "LINES"
-	LINE_STRIP     ShapeType = "LINE_STRIP"
-	LINE_LOOP      ShapeType = "LINE_LOOP"
-	TRIANGLES      ShapeType = "TRIANGLES"
-	TRIANGLE_FAN   ShapeType = "TRIANGLE_FAN"
-	TRIANGLE_STRIP ShapeType = "TRIANGLE_STRIP"
-	QUADS          ShapeType = "QUADS"
-	QUAD_STRIP     ShapeType = "QUAD_STRIP"
-	TESS           ShapeType = "TESS"
-	CLOSE          ShapeType = "CLOSE"
-	OPEN           ShapeType = "OPEN"
-	CHORD          ShapeType = "CHORD"
-	PIE            ShapeType = "PIE"
-	PROJECT        ShapeType = "PROJECT"
-	SQUARE         ShapeType = "SQUARE"
-	ROUND          ShapeType = "ROUND"
-	BEVEL          ShapeType = "BEVEL"
-	MITER          ShapeType = "MITER"
-)
-
-// BlendMode represents the blending mode
-type BlendMode string
-
-const (
-	// Blend modes
-	BLEND      BlendMode = "source-over"
-	REMOVE     BlendMode = "destination-out"
-	ADD        BlendMode = "lighter"
-	DARKEST    BlendMode = "darken"
-	LIGHTEST   BlendMode = "lighten"
-	DIFFERENCE BlendMode = "difference"
-	SUBTRACT   BlendMode = "subtract"
-	EXCLUSION  BlendMode = "exclusion"
-	MULTIPLY   BlendMode = "multiply"
-	SCREEN     BlendMode = "screen"
-	REPLACE    BlendMode = "copy"
-	OVERLAY    BlendMode = "overlay"
-	HARD_LIGHT BlendMode = "hard-light"
-	SOFT_LIGHT BlendMode = "soft-light"
-	DODGE      BlendMode = "color-dodge"
-	BURN       BlendMode = "color-burn"
-)
-
-// FilterType represents the type of filter
-type FilterType string
-
-const (
-	// Image filters
-	THRESHOLD FilterType = "threshold"
-	GRAY      FilterType = "gray"
-	OPAQUE    FilterType = "opaque"
-	INVERT    FilterType = "invert"
-	POSTERIZE FilterType = "posterize"
-	DILATE    FilterType = "dilate"
-	ERODE     FilterType = "erode"
-	BLUR      FilterType = "blur"
-)
-
-// TextStyle represents the style of text
-type TextStyle string
-
-const (
-	// Typography
-	NORMAL     TextStyle = "normal"
-	ITALIC     TextStyle = "italic"
-	BOLD       TextStyle = "bold"
-	BOLDITALIC TextStyle = "bold italic"
-)
-
-// WebGLMode represents the WebGL mode
-type WebGLMode string
-
-const (
-	// Web GL specific
-	IMMEDIATE WebGLMode = "immediate"
-	IMAGE     WebGLMode = "image"
-	NEAREST   WebGLMode = "nearest"
-	REPEAT    WebGLMode = "repeat"
-	CLAMP     WebGLMode = "clamp"
-	MIRROR    WebGLMode = "mirror"
-)
-
-// Orientation represents the device orientation
-type Orientation string
-
-const (
-	// Device orientation
-	LANDSCAPE Orientation = "landscape"
-	PORTRAIT  Orientation = "portrait"
+	"time"
 )
 
 var (
@@ -199,11 +41,29 @@ func Run(query string, fs ...Func) error {
 
 	c := &Canvas{
 		p5Instance:   js.Undefined(),
+		container:    container,
 		funcHandlers: map[string]js.Func{},
+		domHandlers:  map[string][]js.Func{},
+		gamepadFuncs: map[string]GamepadHandler{},
+
+		inputHandlers: map[string][]func(e InputEvent) any{},
 	}
 
 	sketch := js.FuncOf(func(this js.Value, args []js.Value) any {
 		c.p5Instance = args[0]
+		c.p5Instance.Call("registerMethod", "pre", js.FuncOf(func(value js.Value, args []js.Value) any {
+			if c.exportTile != nil {
+				c.exportTile()
+				return nil
+			}
+			if f := c.pendingExport; f != nil {
+				c.pendingExport = nil
+				f()
+				c.p5Instance.Call("resetMatrix")
+			}
+			c.stepInput()
+			return nil
+		}))
 		for _, f := range fs {
 			f(c)
 		}
@@ -217,6 +77,12 @@ func Run(query string, fs ...Func) error {
 	p5Constructor := global.Get("p5")
 	p5Constructor.New(sketch, container)
 
+	for event, handlers := range c.domHandlers {
+		for _, handler := range handlers {
+			container.Call("addEventListener", event, handler)
+		}
+	}
+
 	if err := c.Validate(); err != nil {
 		return err
 	}
@@ -260,7 +126,9 @@ func Draw(handler func(c *Canvas)) Func {
 // MouseMoved sets the mouseMoved handler for the canvas.
 func MouseMoved(handler func(c *Canvas)) Func {
 	return func(c *Canvas) {
-		c.funcHandlers["mouseMoved"] = js.FuncOf(func(value js.Value, args []js.Value) any {
+		c.handleInput("mouseMoved", false, func(args []js.Value) InputEvent {
+			return c.event("mouseMoved")
+		}, func(e InputEvent) any {
 			handler(c)
 			return nil
 		})
@@ -269,119 +137,95 @@ func MouseMoved(handler func(c *Canvas)) Func {
 
 // MouseDragged sets the mouseDragged handler with a MouseDraggedEvent
 func MouseDragged(handler MouseDraggedHandler) Func {
-	return func(c *Canvas) {
-		c.funcHandlers["mouseDragged"] = js.FuncOf(func(value js.Value, args []js.Value) any {
-			e := MouseDraggedEvent{
-				X:       c.MouseX(),
-				Y:       c.MouseY(),
-				Button:  c.MouseButton(),
-				Pressed: c.MouseIsPressed(),
-			}
-			handler(c, e)
-			return nil
-		})
-	}
+	return mouseHandler("mouseDragged", func(c *Canvas, e MouseEvent) {
+		handler(c, MouseDraggedEvent(e))
+	})
 }
 
 // MousePressed sets the mousePressed handler with a MouseEvent
 func MousePressed(handler MousePressedHandler) Func {
-	return func(c *Canvas) {
-		c.funcHandlers["mousePressed"] = js.FuncOf(func(value js.Value, args []js.Value) any {
-			e := MouseEvent{
-				X:       c.MouseX(),
-				Y:       c.MouseY(),
-				Button:  c.MouseButton(),
-				Pressed: c.MouseIsPressed(),
-			}
-			handler(c, e)
-			return nil
-		})
-	}
+	return mouseHandler("mousePressed", func(c *Canvas, e MouseEvent) {
+		handler(c, e)
+	})
 }
 
 // MouseReleased sets the mouseReleased handler with a MouseReleasedEvent
 func MouseReleased(handler MouseReleasedHandler) Func {
-	return func(c *Canvas) {
-		c.funcHandlers["mouseReleased"] = js.FuncOf(func(value js.Value, args []js.Value) any {
-			e := MouseReleasedEvent{
-				X:       c.MouseX(),
-				Y:       c.MouseY(),
-				Button:  c.MouseButton(),
-				Pressed: c.MouseIsPressed(),
-			}
-			handler(c, e)
-			return nil
-		})
-	}
+	return mouseHandler("mouseReleased", func(c *Canvas, e MouseEvent) {
+		handler(c, MouseReleasedEvent(e))
+	})
 }
 
 // MouseClicked sets the mouseClicked handler with a MouseClickedEvent
 func MouseClicked(handler MouseClickedHandler) Func {
-	return func(c *Canvas) {
-		c.funcHandlers["mouseClicked"] = js.FuncOf(func(value js.Value, args []js.Value) any {
-			e := MouseClickedEvent{
-				X:       c.MouseX(),
-				Y:       c.MouseY(),
-				Button:  c.MouseButton(),
-				Pressed: c.MouseIsPressed(),
-			}
-			handler(c, e)
-			return nil
-		})
-	}
+	return mouseHandler("mouseClicked", func(c *Canvas, e MouseEvent) {
+		handler(c, MouseClickedEvent(e))
+	})
 }
 
 // DoubleClicked sets the doubleClicked handler with a DoubleClickedEvent
 func DoubleClicked(handler DoubleClickedHandler) Func {
-	return func(c *Canvas) {
-		c.funcHandlers["doubleClicked"] = js.FuncOf(func(value js.Value, args []js.Value) any {
-			e := DoubleClickedEvent{
-				X:       c.MouseX(),
-				Y:       c.MouseY(),
-				Button:  c.MouseButton(),
-				Pressed: c.MouseIsPressed(),
-			}
-			handler(c, e)
-			return nil
-		})
-	}
+	return mouseHandler("doubleClicked", func(c *Canvas, e MouseEvent) {
+		handler(c, DoubleClickedEvent(e))
+	})
 }
 
-// MouseWheel sets the mouseWheel handler for the canvas.
-func MouseWheel(handler func(c *Canvas)) Func {
+func mouseHandler(method string, handler func(c *Canvas, e MouseEvent)) Func {
 	return func(c *Canvas) {
-		c.funcHandlers["mouseWheel"] = js.FuncOf(func(value js.Value, args []js.Value) any {
-			handler(c)
+		c.handleInput(method, false, func(args []js.Value) InputEvent {
+			e := c.event(method)
+			m := newMouseEvent(c, args)
+			e.Mouse = &m
+			return e
+		}, func(e InputEvent) any {
+			handler(c, *e.Mouse)
 			return nil
 		})
 	}
 }
 
-// KeyPressed sets the keyPressed handler for the canvas.
-func KeyPressed(handler func(c *Canvas)) Func {
+// MouseWheel sets the mouseWheel handler with a WheelEvent.
+// If the handler returns true, the default page scrolling is prevented.
+func MouseWheel(handler MouseWheelHandler) Func {
 	return func(c *Canvas) {
-		c.funcHandlers["keyPressed"] = js.FuncOf(func(value js.Value, args []js.Value) any {
-			handler(c)
+		c.handleInput("mouseWheel", false, func(args []js.Value) InputEvent {
+			e := c.event("mouseWheel")
+			w := newWheelEvent(c, args)
+			e.Wheel = &w
+			return e
+		}, func(e InputEvent) any {
+			if handler(c, *e.Wheel) {
+				return false
+			}
 			return nil
 		})
 	}
 }
 
-// KeyReleased sets the keyReleased handler for the canvas.
-func KeyReleased(handler func(c *Canvas)) Func {
-	return func(c *Canvas) {
-		c.funcHandlers["keyReleased"] = js.FuncOf(func(value js.Value, args []js.Value) any {
-			handler(c)
-			return nil
-		})
-	}
+// KeyPressed sets the keyPressed handler with a KeyEvent
+func KeyPressed(handler KeyPressedHandler) Func {
+	return keyHandler("keyPressed", handler)
+}
+
+// KeyReleased sets the keyReleased handler with a KeyEvent
+func KeyReleased(handler KeyReleasedHandler) Func {
+	return keyHandler("keyReleased", handler)
 }
 
-// KeyTyped sets the keyTyped handler for the canvas.
-func KeyTyped(handler func(c *Canvas)) Func {
+// KeyTyped sets the keyTyped handler with a KeyEvent
+func KeyTyped(handler KeyTypedHandler) Func {
+	return keyHandler("keyTyped", handler)
+}
+
+func keyHandler(method string, handler func(c *Canvas, e KeyEvent)) Func {
 	return func(c *Canvas) {
-		c.funcHandlers["keyTyped"] = js.FuncOf(func(value js.Value, args []js.Value) any {
-			handler(c)
+		c.handleInput(method, false, func(args []js.Value) InputEvent {
+			e := c.event(method)
+			k := newKeyEvent(c, args)
+			e.Key = &k
+			return e
+		}, func(e InputEvent) any {
+			handler(c, *e.Key)
 			return nil
 		})
 	}
@@ -390,9 +234,25 @@ func KeyTyped(handler func(c *Canvas)) Func {
 // Canvas represents a p5.js canvas.
 type Canvas struct {
 	p5Instance   js.Value
+	container    js.Value
 	funcHandlers map[string]js.Func
-	width        float64
-	height       float64
+	domHandlers  map[string][]js.Func
+	gamepads     *GamepadPoller
+	gamepadFuncs map[string]GamepadHandler
+
+	inputHandlers map[string][]func(e InputEvent) any
+	recorder      *Recorder
+	player        *Player
+	replayState   *InputState
+	responsive    *responsive
+	screen        screenState
+	exportTile    func()
+	pendingExport func()
+	rng           *rand.Rand
+	batch         []byte
+	scenes        *sceneManager
+
+	curveTightness float64
 }
 
 // Validate checks if the p5.js instance and required handlers are set.
@@ -411,8 +271,6 @@ func (c *Canvas) Validate() error {
 
 // CreateCanvas creates a new canvas with the specified width and height.
 func (c *Canvas) CreateCanvas(w, h int, opts ...RendererMode) {
-	c.width = float64(w)
-	c.height = float64(h)
 	if len(opts) > 0 {
 		c.p5Instance.Call("createCanvas", w, h, string(opts[0]))
 	} else {
@@ -573,11 +431,45 @@ func (c *Canvas) LoadImage(path string) js.Value {
 	return c.p5Instance.Call("loadImage", path)
 }
 
+// LoadJSON loads a JSON file and passes its text to callback. Called from the preload handler,
+// setup waits until the file is loaded. The text is read as is with loadStrings rather than parsed
+// by loadJSON, which would reorder the keys of objects that look like integers.
+func (c *Canvas) LoadJSON(path string, callback func(data []byte, err error)) {
+	var success, failure js.Func
+	success = js.FuncOf(func(this js.Value, args []js.Value) any {
+		success.Release()
+		failure.Release()
+		lines := make([]string, args[0].Length())
+		for i := range lines {
+			lines[i] = args[0].Index(i).String()
+		}
+		callback([]byte(strings.Join(lines, "\n")), nil)
+		return nil
+	})
+	failure = js.FuncOf(func(this js.Value, args []js.Value) any {
+		success.Release()
+		failure.Release()
+		callback(nil, fmt.Errorf("load %s: %s", path, args[0].Call("toString").String()))
+		return nil
+	})
+	c.p5Instance.Call("loadStrings", path, success, failure)
+}
+
 // Image draws an image on the canvas.
 func (c *Canvas) Image(img any, opts ...any) {
 	c.p5Instance.Call("image", append([]any{img}, opts...)...)
 }
 
+// Tint sets the fill value for displaying images.
+func (c *Canvas) Tint(args ...any) {
+	c.p5Instance.Call("tint", args...)
+}
+
+// NoTint removes the current tint for displaying images.
+func (c *Canvas) NoTint() {
+	c.p5Instance.Call("noTint")
+}
+
 // FrameRate sets the frame rate for the canvas.
 func (c *Canvas) FrameRate(fps float64) {
 	c.p5Instance.Call("frameRate", fps)
@@ -588,6 +480,41 @@ func (c *Canvas) Random(min, max float64) float64 {
 	return c.p5Instance.Call("random", min, max).Float()
 }
 
+// RandomSeed sets the seed of Random and Rand so that they return the same sequence on every run.
+func (c *Canvas) RandomSeed(seed int64) {
+	c.p5Instance.Call("randomSeed", seed)
+	c.rng = rand.New(rand.NewSource(seed))
+}
+
+// Rand returns the Go random generator of the sketch, for the packages that take a *rand.Rand.
+// It is seeded by RandomSeed, and by the current time until RandomSeed is called.
+func (c *Canvas) Rand() *rand.Rand {
+	if c.rng == nil {
+		c.rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return c.rng
+}
+
+// Noise returns the Perlin noise value between 0 and 1 at the given coordinates.
+// y and z may be omitted for 1D and 2D noise.
+func (c *Canvas) Noise(x float64, yz ...float64) float64 {
+	args := []any{x}
+	for _, v := range yz {
+		args = append(args, v)
+	}
+	return c.p5Instance.Call("noise", args...).Float()
+}
+
+// NoiseDetail sets the number of octaves and the falloff of each octave of Noise.
+func (c *Canvas) NoiseDetail(octaves int, falloff float64) {
+	c.p5Instance.Call("noiseDetail", octaves, falloff)
+}
+
+// NoiseSeed sets the seed of the noise generator.
+func (c *Canvas) NoiseSeed(seed int64) {
+	c.p5Instance.Call("noiseSeed", seed)
+}
+
 // Map maps a value from one range to another.
 func (c *Canvas) Map(value, start1, stop1, start2, stop2 float64) float64 {
 	return c.p5Instance.Call("map", value, start1, stop1, start2, stop2).Float()
@@ -596,7 +523,7 @@ func (c *Canvas) Map(value, start1, stop1, start2, stop2 float64) float64 {
 // BeginShape begins recording vertices for a shape.
 func (c *Canvas) BeginShape(kind ...ShapeType) {
 	if len(kind) > 0 {
-		c.p5Instance.Call("beginShape", js.Global().Get(string(kind[0])))
+		c.p5Instance.Call("beginShape", c.p5Instance.Get(string(kind[0])))
 	} else {
 		c.p5Instance.Call("beginShape")
 	}
@@ -614,7 +541,7 @@ func (c *Canvas) Vertex(x, y float64, z ...float64) {
 // EndShape ends recording vertices for a shape.
 func (c *Canvas) EndShape(mode ...ShapeType) {
 	if len(mode) > 0 {
-		c.p5Instance.Call("endShape", js.Global().Get(string(mode[0])))
+		c.p5Instance.Call("endShape", c.p5Instance.Get(string(mode[0])))
 	} else {
 		c.p5Instance.Call("endShape")
 	}
@@ -657,42 +584,70 @@ func (c *Canvas) TextWrap(w DrawingMode) {
 
 // MouseX returns the current x-coordinate of the mouse.
 func (c *Canvas) MouseX() float64 {
+	if s := c.replayState; s != nil {
+		return s.MouseX
+	}
 	return c.p5Instance.Get("mouseX").Float()
 }
 
 // PMouseX returns the previous x-coordinate of the mouse.
 func (c *Canvas) PMouseX() float64 {
+	if s := c.replayState; s != nil {
+		return s.PMouseX
+	}
 	return c.p5Instance.Get("pmouseX").Float()
 }
 
 // MouseY returns the current y-coordinate of the mouse.
 func (c *Canvas) MouseY() float64 {
+	if s := c.replayState; s != nil {
+		return s.MouseY
+	}
 	return c.p5Instance.Get("mouseY").Float()
 }
 
 // PMouseY returns the previous y-coordinate of the mouse.
 func (c *Canvas) PMouseY() float64 {
+	if s := c.replayState; s != nil {
+		return s.PMouseY
+	}
 	return c.p5Instance.Get("pmouseY").Float()
 }
 
 // MouseIsPressed returns true if the mouse is currently pressed.
 func (c *Canvas) MouseIsPressed() bool {
+	if s := c.replayState; s != nil {
+		return s.MouseIsPressed
+	}
 	return c.p5Instance.Get("mouseIsPressed").Bool()
 }
 
 // MovedX returns the amount the mouse has moved along the x-axis.
 func (c *Canvas) MovedX() float64 {
+	if s := c.replayState; s != nil {
+		return s.MovedX
+	}
 	return c.p5Instance.Get("movedX").Float()
 }
 
 // MovedY returns the amount the mouse has moved along the y-axis.
 func (c *Canvas) MovedY() float64 {
+	if s := c.replayState; s != nil {
+		return s.MovedY
+	}
 	return c.p5Instance.Get("movedY").Float()
 }
 
 // MouseButton returns the current mouse button being pressed.
-func (c *Canvas) MouseButton() string {
-	return c.p5Instance.Get("mouseButton").String()
+func (c *Canvas) MouseButton() MouseButton {
+	if s := c.replayState; s != nil {
+		return s.MouseButton
+	}
+	v := c.p5Instance.Get("mouseButton")
+	if v.Type() != js.TypeString {
+		return ""
+	}
+	return MouseButton(v.String())
 }
 
 // SaveGif saves the canvas as a GIF file.
@@ -702,19 +657,41 @@ func (c *Canvas) SaveGif(name string, second float64) {
 
 // Key returns the current key being pressed.
 func (c *Canvas) Key() string {
+	if s := c.replayState; s != nil {
+		return s.Key
+	}
 	return c.p5Instance.Get("key").String()
 }
 
 // KeyCode returns the key code of the current key being pressed.
-func (c *Canvas) KeyCode() int {
-	return c.p5Instance.Get("keyCode").Int()
+func (c *Canvas) KeyCode() KeyCode {
+	if s := c.replayState; s != nil {
+		return s.KeyCode
+	}
+	return KeyCode(c.p5Instance.Get("keyCode").Int())
 }
 
 // KeyIsPressed returns true if a key is currently pressed.
 func (c *Canvas) KeyIsPressed() bool {
+	if s := c.replayState; s != nil {
+		return s.KeyIsPressed
+	}
 	return c.p5Instance.Get("keyIsPressed").Bool()
 }
 
+// KeyIsDown returns true if the key with the specified code is currently held down.
+func (c *Canvas) KeyIsDown(code KeyCode) bool {
+	if s := c.replayState; s != nil {
+		for _, k := range s.KeysDown {
+			if k == code {
+				return true
+			}
+		}
+		return false
+	}
+	return c.p5Instance.Call("keyIsDown", int(code)).Bool()
+}
+
 // ColorMode sets the color mode for the canvas.
 func (c *Canvas) ColorMode(mode ColorMode, max ...float64) {
 	if len(max) > 0 {
@@ -799,6 +776,22 @@ func (c *Canvas) FrameCount() int {
 	return c.p5Instance.Get("frameCount").Int()
 }
 
+// Millis returns the number of milliseconds since the sketch started.
+func (c *Canvas) Millis() float64 {
+	if s := c.replayState; s != nil {
+		return s.Millis
+	}
+	return c.p5Instance.Call("millis").Float()
+}
+
+// DeltaTime returns the number of milliseconds since the previous frame.
+func (c *Canvas) DeltaTime() float64 {
+	if s := c.replayState; s != nil {
+		return s.DeltaTime
+	}
+	return c.p5Instance.Get("deltaTime").Float()
+}
+
 // GetFrameRate returns the current frame rate.
 func (c *Canvas) GetFrameRate() float64 {
 	return c.p5Instance.Get("frameRate").Float()
@@ -931,22 +924,28 @@ func (c *Canvas) NoCursor() {
 
 // WindowWidth returns the width of the window.
 func (c *Canvas) WindowWidth() float64 {
+	if s := c.replayState; s != nil {
+		return s.WindowWidth
+	}
 	return c.p5Instance.Get("windowWidth").Float()
 }
 
 // WindowHeight returns the height of the window.
 func (c *Canvas) WindowHeight() float64 {
+	if s := c.replayState; s != nil {
+		return s.WindowHeight
+	}
 	return c.p5Instance.Get("windowHeight").Float()
 }
 
 // Width returns the width of the canvas.
 func (c *Canvas) Width() float64 {
-	return c.width
+	return c.p5Instance.Get("width").Float()
 }
 
 // Height returns the height of the canvas.
 func (c *Canvas) Height() float64 {
-	return c.height
+	return c.p5Instance.Get("height").Float()
 }
 
 // ApplyMatrix applies a transformation matrix to the canvas.
@@ -1140,10 +1139,9 @@ func (c *Canvas) CreateCapture(kind CaptureKind) js.Value {
 }
 
 // Size sets the size of the canvas.
+// It is the same as ResizeCanvas.
 func (c *Canvas) Size(width, height float64) {
-	c.width = width
-	c.height = height
-	c.p5Instance.Call("size", width, height)
+	c.ResizeCanvas(int(width), int(height))
 }
 
 // Hide hides the canvas.
@@ -1151,38 +1149,6 @@ func (c *Canvas) Hide() {
 	c.p5Instance.Call("hide")
 }
 
-// Color represents a color with RGBA components
-type Color struct {
-	R, G, B, A float64
-}
-
-// Vector represents a 2D vector
-type Vector struct {
-	X, Y float64
-}
-
-// Rectangle represents a rectangle with position and size
-type Rectangle struct {
-	Position Vector
-	Size     Vector
-}
-
-// Circle represents a circle with center position and diameter
-type Circle struct {
-	Position Vector
-	Diameter float64
-}
-
-// Line represents a line with start and end points
-type Line struct {
-	Start, End Vector
-}
-
-// Triangle represents a triangle with three vertices
-type Triangle struct {
-	V1, V2, V3 Vector
-}
-
 // FillRGB sets the fill color using RGB values
 func (c *Canvas) FillRGB(r, g, b float64) {
 	c.Fill(r, g, b)
@@ -1247,52 +1213,83 @@ func (c *Canvas) Box(opts ...any) {
 	}
 }
 
-// MouseEvent represents a mouse event
-type MouseEvent struct {
-	X, Y    float64
-	Button  string
-	Pressed bool
-}
-
 // MousePressedHandler is a type for mouse pressed event handlers
 type MousePressedHandler func(c *Canvas, e MouseEvent)
 
-// MouseDraggedEvent represents a mouse dragged event
-type MouseDraggedEvent struct {
-	X, Y    float64
-	Button  string
-	Pressed bool
-}
-
 // MouseDraggedHandler is a type for mouse dragged event handlers
 type MouseDraggedHandler func(c *Canvas, e MouseDraggedEvent)
 
-// MouseReleasedEvent represents a mouse released event
-type MouseReleasedEvent struct {
-	X, Y    float64
-	Button  string
-	Pressed bool
-}
-
 // MouseReleasedHandler is a type for mouse released event handlers
 type MouseReleasedHandler func(c *Canvas, e MouseReleasedEvent)
 
-// MouseClickedEvent represents a mouse clicked event
-type MouseClickedEvent struct {
-	X, Y    float64
-	Button  string
-	Pressed bool
-}
-
 // MouseClickedHandler is a type for mouse clicked event handlers
 type MouseClickedHandler func(c *Canvas, e MouseClickedEvent)
 
-// DoubleClickedEvent represents a double clicked event
-type DoubleClickedEvent struct {
-	X, Y    float64
-	Button  string
-	Pressed bool
-}
-
 // DoubleClickedHandler is a type for double clicked event handlers
 type DoubleClickedHandler func(c *Canvas, e DoubleClickedEvent)
+
+// newMouseEvent builds a MouseEvent from the p5 mouse state and the native event, if any.
+func newMouseEvent(c *Canvas, args []js.Value) MouseEvent {
+	e := MouseEvent{
+		PointerEvent: PointerEvent{
+			Type:   PointerMouse,
+			X:      c.MouseX(),
+			Y:      c.MouseY(),
+			Button: c.MouseButton(),
+		},
+		Pressed: c.MouseIsPressed(),
+	}
+	if e.Pressed {
+		// Pointer Events report 0.5 for a pressed mouse without pressure support.
+		e.Pressure = 0.5
+	}
+	if len(args) > 0 && args[0].Truthy() {
+		readPointer(&e.PointerEvent, args[0])
+	}
+	return e
+}
+
+// MouseWheelHandler is a type for mouse wheel event handlers.
+// Returning true prevents the default page scrolling.
+type MouseWheelHandler func(c *Canvas, e WheelEvent) bool
+
+// newWheelEvent builds a WheelEvent from the mouse position and the native WheelEvent, if any.
+func newWheelEvent(c *Canvas, args []js.Value) WheelEvent {
+	e := WheelEvent{
+		X: c.MouseX(),
+		Y: c.MouseY(),
+	}
+	if len(args) == 0 || !args[0].Truthy() {
+		return e
+	}
+	ev := args[0]
+	e.DeltaX = ev.Get("deltaX").Float()
+	e.DeltaY = ev.Get("deltaY").Float()
+	e.DeltaMode = ev.Get("deltaMode").Int()
+	e.Ctrl, e.Alt, e.Shift, e.Meta = modifiers(ev)
+	return e
+}
+
+// KeyPressedHandler is a type for key pressed event handlers
+type KeyPressedHandler func(c *Canvas, e KeyEvent)
+
+// KeyReleasedHandler is a type for key released event handlers
+type KeyReleasedHandler func(c *Canvas, e KeyEvent)
+
+// KeyTypedHandler is a type for key typed event handlers
+type KeyTypedHandler func(c *Canvas, e KeyEvent)
+
+// newKeyEvent builds a KeyEvent from the p5 key state and the native KeyboardEvent, if any.
+func newKeyEvent(c *Canvas, args []js.Value) KeyEvent {
+	e := KeyEvent{
+		Key:  c.Key(),
+		Code: c.KeyCode(),
+	}
+	if len(args) == 0 || !args[0].Truthy() {
+		return e
+	}
+	ev := args[0]
+	e.Ctrl, e.Alt, e.Shift, e.Meta = modifiers(ev)
+	e.Repeat = ev.Get("repeat").Truthy()
+	return e
+}
@@ -0,0 +1,75 @@
+package particle
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/ryomak/p5go"
+)
+
+// Emitter creates particles at a position. Values with a Variance field are randomized by up to
+// that fraction in either direction.
+type Emitter struct {
+	Position p5go.Vector
+	Radius   float64 // particles start anywhere within this distance of Position
+	Rate     float64 // particles per second emitted by Update
+
+	Angle            float64 // direction of the initial velocity, in radians
+	Spread           float64 // total angle the direction is spread over, in radians
+	Speed            float64
+	SpeedVariance    float64
+	Lifetime         float64 // seconds, 1 if zero
+	LifetimeVariance float64
+	Mass             float64 // 1 if zero
+
+	// Size and Color give the diameter and color of a particle over its life, from 0 to 1.
+	// The defaults are a diameter of 4 and opaque white.
+	Size  func(t float64) float64
+	Color func(t float64) p5go.Color
+
+	pending float64
+}
+
+// spawn initializes p as a new particle of the emitter.
+func (e *Emitter) spawn(rng *rand.Rand, p *Particle) {
+	*p = Particle{
+		Position: e.Position,
+		Mass:     e.Mass,
+		Lifetime: e.Lifetime,
+		emitter:  e,
+	}
+	if p.Mass == 0 {
+		p.Mass = 1
+	}
+	if p.Lifetime <= 0 {
+		p.Lifetime = 1
+	}
+	p.Lifetime = vary(rng, p.Lifetime, e.LifetimeVariance)
+	if e.Radius > 0 {
+		// uniform in the disc
+		p.Position = p.Position.Add(p5go.FromAngle(rng.Float64()*p5go.TWO_PI, e.Radius*math.Sqrt(rng.Float64())))
+	}
+	angle := e.Angle + (rng.Float64()-0.5)*e.Spread
+	p.Velocity = p5go.FromAngle(angle, vary(rng, e.Speed, e.SpeedVariance))
+	p.style()
+}
+
+// style updates the size and color of the particle for its age.
+func (p *Particle) style() {
+	t := p.Life()
+	p.Size = 4
+	p.Color = p5go.Color{R: 255, G: 255, B: 255, A: 255}
+	if p.emitter.Size != nil {
+		p.Size = p.emitter.Size(t)
+	}
+	if p.emitter.Color != nil {
+		p.Color = p.emitter.Color(t)
+	}
+}
+
+func vary(rng *rand.Rand, v, variance float64) float64 {
+	if variance == 0 {
+		return v
+	}
+	return v * (1 + variance*(rng.Float64()*2-1))
+}
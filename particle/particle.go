@@ -0,0 +1,106 @@
+// Package particle provides a pooled particle system with emitters, forces and properties that
+// change over the life of each particle.
+package particle
+
+import (
+	"github.com/ryomak/p5go"
+)
+
+// Particle is a point moving under the forces of its system.
+type Particle struct {
+	Position p5go.Vector
+	Velocity p5go.Vector
+	Mass     float64
+	Age      float64 // seconds since it was emitted
+	Lifetime float64 // seconds it lives
+	Size     float64 // diameter
+	Color    p5go.Color
+
+	force   p5go.Vector
+	emitter *Emitter
+}
+
+// Life returns the age of the particle as a fraction of its lifetime, between 0 and 1.
+func (p *Particle) Life() float64 {
+	if p.Lifetime <= 0 {
+		return 1
+	}
+	return min(1, p.Age/p.Lifetime)
+}
+
+// ApplyForce adds a force for the current update.
+func (p *Particle) ApplyForce(f p5go.Vector) {
+	p.force = p.force.Add(f)
+}
+
+// Force acts on every particle of a system at each update.
+type Force interface {
+	Apply(p *Particle, dt float64)
+}
+
+// ForceFunc is a function used as a Force.
+type ForceFunc func(p *Particle, dt float64)
+
+// Apply calls f.
+func (f ForceFunc) Apply(p *Particle, dt float64) {
+	f(p, dt)
+}
+
+// Gravity accelerates every particle by g, whatever its mass.
+func Gravity(g p5go.Vector) Force {
+	return ForceFunc(func(p *Particle, dt float64) {
+		p.ApplyForce(g.Mult(p.Mass))
+	})
+}
+
+// Drag slows particles down with a force proportional to their velocity.
+func Drag(coefficient float64) Force {
+	return ForceFunc(func(p *Particle, dt float64) {
+		p.ApplyForce(p.Velocity.Mult(-coefficient))
+	})
+}
+
+// Attractor pulls particles towards position with a force of strength / distance², or pushes
+// them away when strength is negative. Distances below minDistance are clamped to avoid huge forces.
+func Attractor(position p5go.Vector, strength, minDistance float64) Force {
+	return ForceFunc(func(p *Particle, dt float64) {
+		d := position.Sub(p.Position)
+		dist := max(d.Mag(), minDistance)
+		if dist == 0 {
+			return
+		}
+		p.ApplyForce(d.SetMag(strength * p.Mass / (dist * dist)))
+	})
+}
+
+// Linear returns a curve from a to b over the life of a particle.
+func Linear(a, b float64) func(t float64) float64 {
+	return func(t float64) float64 {
+		return a + (b-a)*t
+	}
+}
+
+// Gradient returns a color curve through evenly spaced colors over the life of a particle.
+func Gradient(colors ...p5go.Color) func(t float64) p5go.Color {
+	return func(t float64) p5go.Color {
+		if len(colors) == 0 {
+			return p5go.Color{}
+		}
+		if len(colors) == 1 || t <= 0 {
+			return colors[0]
+		}
+		if t >= 1 {
+			return colors[len(colors)-1]
+		}
+		f := t * float64(len(colors)-1)
+		i := int(f)
+		u := f - float64(i)
+		a, b := colors[i], colors[i+1]
+		return p5go.Color{
+			R: a.R + (b.R-a.R)*u,
+			G: a.G + (b.G-a.G)*u,
+			B: a.B + (b.B-a.B)*u,
+			A: a.A + (b.A-a.A)*u,
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package particle
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/ryomak/p5go"
+)
+
+// CircleBatcher is implemented by renderers that draw many circles in one call, such as
+// p5go.Canvas. The data holds p5go.CircleBatchStride values per circle.
+type CircleBatcher interface {
+	Circles(data []float32)
+}
+
+// System owns a fixed pool of particles, so emitting and expiring particles does not allocate.
+type System struct {
+	Emitters []*Emitter
+	Forces   []Force
+
+	rng       *rand.Rand
+	particles []Particle // live particles first, then the free pool
+	live      int
+	batch     []float32
+}
+
+// New creates a system holding at most capacity particles. rng randomizes the emitted particles,
+// such as Canvas.Rand for a seeded sketch. If rng is nil a source seeded with the time is used.
+func New(rng *rand.Rand, capacity int) *System {
+	if rng == nil {
+		rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return &System{rng: rng, particles: make([]Particle, capacity)}
+}
+
+// AddEmitter adds an emitter to the system and returns it.
+func (s *System) AddEmitter(e *Emitter) *Emitter {
+	s.Emitters = append(s.Emitters, e)
+	return e
+}
+
+// AddForce adds a force acting on every particle.
+func (s *System) AddForce(f Force) {
+	s.Forces = append(s.Forces, f)
+}
+
+// Len returns the number of live particles.
+func (s *System) Len() int {
+	return s.live
+}
+
+// Cap returns the maximum number of particles.
+func (s *System) Cap() int {
+	return len(s.particles)
+}
+
+// Particles returns the live particles. The slice is reused, so it is only valid until the next
+// call to Update or Emit.
+func (s *System) Particles() []Particle {
+	return s.particles[:s.live]
+}
+
+// Clear removes every particle.
+func (s *System) Clear() {
+	s.live = 0
+}
+
+// Emit emits n particles from e at once. Particles that do not fit in the pool are dropped.
+// It returns the number of particles emitted.
+func (s *System) Emit(e *Emitter, n int) int {
+	n = min(n, len(s.particles)-s.live)
+	for i := 0; i < n; i++ {
+		e.spawn(s.rng, &s.particles[s.live])
+		s.live++
+	}
+	return max(n, 0)
+}
+
+// Update advances the system by dt seconds: emitters emit at their rate, forces are applied,
+// particles move and those past their lifetime return to the pool.
+func (s *System) Update(dt float64) {
+	for _, e := range s.Emitters {
+		e.pending += e.Rate * dt
+		if n := int(e.pending); n > 0 {
+			e.pending -= float64(n)
+			s.Emit(e, n)
+		}
+	}
+	for i := 0; i < s.live; i++ {
+		p := &s.particles[i]
+		p.Age += dt
+		if p.Age >= p.Lifetime {
+			// swap the last live particle into this slot and check it again
+			s.live--
+			s.particles[i], s.particles[s.live] = s.particles[s.live], s.particles[i]
+			i--
+			continue
+		}
+		for _, f := range s.Forces {
+			f.Apply(p, dt)
+		}
+		p.Velocity = p.Velocity.Add(p.force.Div(p.Mass).Mult(dt))
+		p.Position = p.Position.Add(p.Velocity.Mult(dt))
+		p.force = p5go.Vector{}
+		p.style()
+	}
+}
+
+// Draw draws every particle as a filled circle. Renderers implementing CircleBatcher draw the
+// whole system in one call; others get one Fill and Circle call per particle.
+func (s *System) Draw(r p5go.Renderer) {
+	if b, ok := r.(CircleBatcher); ok {
+		s.batch = s.batch[:0]
+		for i := range s.Particles() {
+			p := &s.particles[i]
+			s.batch = append(s.batch,
+				float32(p.Position.X), float32(p.Position.Y), float32(p.Size),
+				float32(p.Color.R), float32(p.Color.G), float32(p.Color.B), float32(p.Color.A))
+		}
+		b.Circles(s.batch)
+		return
+	}
+	for i := range s.Particles() {
+		p := &s.particles[i]
+		r.Fill(p.Color.R, p.Color.G, p.Color.B, p.Color.A)
+		r.Circle(p.Position.X, p.Position.Y, p.Size)
+	}
+}
@@ -0,0 +1,48 @@
+package particle
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/ryomak/p5go"
+)
+
+func TestNilRandAndDefaultLifetime(t *testing.T) {
+	s := New(nil, 10)
+	e := s.AddEmitter(&Emitter{Radius: 5, Spread: math.Pi, Speed: 10, SpeedVariance: 0.5})
+	if n := s.Emit(e, 4); n != 4 {
+		t.Fatalf("Emit = %d, want 4", n)
+	}
+	// an emitter without a lifetime keeps its particles for a second
+	s.Update(0.5)
+	if s.Len() != 4 {
+		t.Errorf("%d particles after half a second, want 4", s.Len())
+	}
+	s.Update(0.6)
+	if s.Len() != 0 {
+		t.Errorf("%d particles after a second, want 0", s.Len())
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	s := New(rand.New(rand.NewSource(1)), 3)
+	s.AddForce(Gravity(p5go.Vector{Y: 10}))
+	e := s.AddEmitter(&Emitter{Rate: 10, Speed: 2, Lifetime: 0.35})
+	s.Update(0.25)
+	if s.Len() != 2 {
+		t.Fatalf("%d particles after 0.25s at 10 per second, want 2", s.Len())
+	}
+	// the pool holds three particles, so the rest are dropped
+	if n := s.Emit(e, 5); n != 1 || s.Len() != 3 {
+		t.Fatalf("Emit into a nearly full pool = %d with %d live, want 1 with 3", n, s.Len())
+	}
+	p := s.Particles()[0]
+	if p.Velocity.X != 2 || p.Velocity.Y <= 0 {
+		t.Errorf("velocity = %v, want right and falling", p.Velocity)
+	}
+	s.Update(0.4)
+	if s.Len() != 0 {
+		t.Errorf("%d particles past their lifetime, want 0", s.Len())
+	}
+}
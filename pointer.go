@@ -0,0 +1,21 @@
+package p5go
+
+// PointerType represents the kind of device that produced a pointer event
+type PointerType string
+
+const (
+	// Pointer types
+	PointerMouse PointerType = "mouse"
+	PointerTouch PointerType = "touch"
+	PointerPen   PointerType = "pen"
+)
+
+// PointerEvent represents a mouse, touch or pen input in canvas coordinates
+type PointerEvent struct {
+	ID                     int
+	Type                   PointerType
+	X, Y                   float64
+	Pressure               float64
+	Button                 MouseButton
+	Ctrl, Alt, Shift, Meta bool
+}
@@ -0,0 +1,166 @@
+//go:build js && wasm
+
+package p5go
+
+import (
+	"syscall/js"
+)
+
+// PointerHandler is a type for pointer event handlers
+type PointerHandler func(c *Canvas, e PointerEvent)
+
+// TouchHandler is a type for touch event handlers.
+// It receives the current touches; returning true prevents the default browser behavior such as scrolling.
+type TouchHandler func(c *Canvas, touches []PointerEvent) bool
+
+// PointerDown sets the pointerdown handler for the canvas.
+func PointerDown(handler PointerHandler) Func {
+	return pointerListener("pointerdown", handler)
+}
+
+// PointerMove sets the pointermove handler for the canvas.
+func PointerMove(handler PointerHandler) Func {
+	return pointerListener("pointermove", handler)
+}
+
+// PointerUp sets the pointerup handler for the canvas.
+func PointerUp(handler PointerHandler) Func {
+	return pointerListener("pointerup", handler)
+}
+
+// PointerCancel sets the pointercancel handler for the canvas.
+func PointerCancel(handler PointerHandler) Func {
+	return pointerListener("pointercancel", handler)
+}
+
+func pointerListener(event string, handler PointerHandler) Func {
+	return func(c *Canvas) {
+		c.handlePointer(event, func(e InputEvent) any {
+			handler(c, *e.Pointer)
+			return nil
+		})
+	}
+}
+
+// handlePointer registers handler for a DOM pointer event on the container.
+func (c *Canvas) handlePointer(event string, handler func(e InputEvent) any) {
+	c.handleInput(event, true, func(args []js.Value) InputEvent {
+		e := c.event(event)
+		if len(args) > 0 {
+			p := c.pointerFromDOM(args[0])
+			e.Pointer = &p
+			if ts := args[0].Get("timeStamp"); ts.Type() == js.TypeNumber {
+				e.State.Time = ts.Float()
+			}
+		}
+		return e
+	}, func(e InputEvent) any {
+		if e.Pointer == nil {
+			return nil
+		}
+		return handler(e)
+	})
+}
+
+// TouchStarted sets the touchStarted handler for the canvas.
+func TouchStarted(handler TouchHandler) Func {
+	return touchHandler("touchStarted", handler)
+}
+
+// TouchMoved sets the touchMoved handler for the canvas.
+func TouchMoved(handler TouchHandler) Func {
+	return touchHandler("touchMoved", handler)
+}
+
+// TouchEnded sets the touchEnded handler for the canvas.
+func TouchEnded(handler TouchHandler) Func {
+	return touchHandler("touchEnded", handler)
+}
+
+func touchHandler(method string, handler TouchHandler) Func {
+	return func(c *Canvas) {
+		c.handleInput(method, false, func(args []js.Value) InputEvent {
+			return c.event(method)
+		}, func(e InputEvent) any {
+			if handler(c, e.State.Touches) {
+				return false
+			}
+			return nil
+		})
+	}
+}
+
+// Touches returns a snapshot of the current touch points.
+// p5.js does not report touch pressure, so Pressure is always 0.
+func (c *Canvas) Touches() []PointerEvent {
+	if s := c.replayState; s != nil {
+		return s.Touches
+	}
+	return c.liveTouches()
+}
+
+func (c *Canvas) liveTouches() []PointerEvent {
+	touches := c.p5Instance.Get("touches")
+	if touches.Type() != js.TypeObject {
+		return nil
+	}
+	n := touches.Length()
+	res := make([]PointerEvent, 0, n)
+	for i := 0; i < n; i++ {
+		t := touches.Index(i)
+		res = append(res, PointerEvent{
+			ID:   t.Get("id").Int(),
+			Type: PointerTouch,
+			X:    t.Get("x").Float(),
+			Y:    t.Get("y").Float(),
+		})
+	}
+	return res
+}
+
+// pointerFromDOM converts a native PointerEvent into canvas coordinates.
+func (c *Canvas) pointerFromDOM(ev js.Value) PointerEvent {
+	var e PointerEvent
+	readPointer(&e, ev)
+
+	el := c.p5Instance.Get("canvas")
+	if !el.Truthy() {
+		el = c.container
+	}
+	rect := el.Call("getBoundingClientRect")
+	e.X = ev.Get("clientX").Float() - rect.Get("left").Float()
+	e.Y = ev.Get("clientY").Float() - rect.Get("top").Float()
+	if w, h := rect.Get("width").Float(), rect.Get("height").Float(); w > 0 && h > 0 && c.Width() > 0 && c.Height() > 0 {
+		e.X *= c.Width() / w
+		e.Y *= c.Height() / h
+	}
+
+	switch ev.Get("button").Int() {
+	case 0:
+		e.Button = MouseButtonLeft
+	case 1:
+		e.Button = MouseButtonCenter
+	case 2:
+		e.Button = MouseButtonRight
+	}
+	return e
+}
+
+// readPointer copies the pointer id, type, pressure and modifiers from a native event.
+func readPointer(e *PointerEvent, ev js.Value) {
+	if id := ev.Get("pointerId"); id.Type() == js.TypeNumber {
+		e.ID = id.Int()
+	}
+	if t := ev.Get("pointerType"); t.Type() == js.TypeString && t.String() != "" {
+		e.Type = PointerType(t.String())
+	}
+	if p := ev.Get("pressure"); p.Type() == js.TypeNumber {
+		e.Pressure = p.Float()
+	}
+	e.Ctrl, e.Alt, e.Shift, e.Meta = modifiers(ev)
+}
+
+// modifiers returns the ctrl, alt, shift and meta key states of a native event.
+func modifiers(ev js.Value) (ctrl, alt, shift, meta bool) {
+	return ev.Get("ctrlKey").Truthy(), ev.Get("altKey").Truthy(), ev.Get("shiftKey").Truthy(), ev.Get("metaKey").Truthy()
+}
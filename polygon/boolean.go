@@ -0,0 +1,323 @@
+package polygon
+
+import (
+	"math"
+	"sort"
+
+	"github.com/ryomak/p5go"
+)
+
+// Op is a boolean operation on two shapes.
+type Op int
+
+const (
+	// Boolean operations
+	OpUnion        Op = iota // inside a or b
+	OpIntersection           // inside a and b
+	OpDifference             // inside a but not b
+	OpXor                    // inside exactly one of a and b
+)
+
+// Union returns the region inside a or b.
+func Union(a, b Shape) Shape {
+	return Boolean(OpUnion, a, b)
+}
+
+// Intersection returns the region inside both a and b.
+func Intersection(a, b Shape) Shape {
+	return Boolean(OpIntersection, a, b)
+}
+
+// Difference returns the region inside a but not b.
+func Difference(a, b Shape) Shape {
+	return Boolean(OpDifference, a, b)
+}
+
+// Xor returns the region inside exactly one of a and b.
+func Xor(a, b Shape) Shape {
+	return Boolean(OpXor, a, b)
+}
+
+// Normalize resolves self intersections and overlapping rings of s with the even-odd rule and
+// orients the rings like the results of the boolean operations.
+func Normalize(s Shape) Shape {
+	return resolve(s, s.Contains)
+}
+
+// Boolean applies op to a and b.
+func Boolean(op Op, a, b Shape) Shape {
+	rings := append(append(Shape{}, a...), b...)
+	return resolve(rings, func(p p5go.Vector) bool {
+		inA, inB := a.Contains(p), b.Contains(p)
+		switch op {
+		case OpUnion:
+			return inA || inB
+		case OpIntersection:
+			return inA && inB
+		case OpDifference:
+			return inA && !inB
+		default:
+			return inA != inB
+		}
+	})
+}
+
+const (
+	// paramEpsilon is the tolerance on the segment parameter used to snap intersections to end points.
+	paramEpsilon = 1e-9
+	// weldEpsilon is the distance, relative to the size of the input, below which vertices are merged.
+	weldEpsilon = 1e-9
+)
+
+type edge struct {
+	a, b p5go.Vector
+}
+
+// resolve builds the outline of the region described by inside from the edges of rings.
+// The edges are split at every crossing, the pieces with the region on exactly one side are kept
+// with the region on their left, and the kept pieces are joined into rings.
+func resolve(rings Shape, inside func(p p5go.Vector) bool) Shape {
+	bounds := rings.Bounds()
+	size := math.Max(bounds.Size.X, bounds.Size.Y)
+	if size == 0 {
+		return nil
+	}
+	edges := splitEdges(ringEdges(rings, size*weldEpsilon))
+	delta := size * 1e-7
+
+	var kept []edge
+	for _, e := range edges {
+		d := e.b.Sub(e.a)
+		n := p5go.Vector{X: -d.Y, Y: d.X}.SetMag(delta)
+		m := e.a.Lerp(e.b, 0.5)
+		left, right := inside(m.Add(n)), inside(m.Sub(n))
+		switch {
+		case left && !right:
+			kept = append(kept, e)
+		case right && !left:
+			kept = append(kept, edge{a: e.b, b: e.a})
+		}
+	}
+	return linkEdges(kept)
+}
+
+// ringEdges returns the edges of every ring, with the vertices closer than tol merged so that
+// rings meeting at a vertex share it exactly.
+func ringEdges(rings Shape, tol float64) []edge {
+	w := welder{tol: tol, cells: map[[2]int64][]p5go.Vector{}}
+	var edges []edge
+	for _, ring := range rings {
+		for i := range ring {
+			e := edge{a: w.weld(ring[i]), b: w.weld(ring[(i+1)%len(ring)])}
+			if e.a != e.b {
+				edges = append(edges, e)
+			}
+		}
+	}
+	return edges
+}
+
+// welder merges points closer than tol, using a hash grid of tol sized cells.
+type welder struct {
+	tol   float64
+	cells map[[2]int64][]p5go.Vector
+}
+
+func (w *welder) weld(p p5go.Vector) p5go.Vector {
+	cx, cy := int64(math.Floor(p.X/w.tol)), int64(math.Floor(p.Y/w.tol))
+	for y := cy - 1; y <= cy+1; y++ {
+		for x := cx - 1; x <= cx+1; x++ {
+			for _, q := range w.cells[[2]int64{x, y}] {
+				if q.Sub(p).MagSq() <= w.tol*w.tol {
+					return q
+				}
+			}
+		}
+	}
+	k := [2]int64{cx, cy}
+	w.cells[k] = append(w.cells[k], p)
+	return p
+}
+
+type split struct {
+	t float64
+	p p5go.Vector
+}
+
+// splitEdges splits the edges at their intersections with each other and removes duplicates.
+func splitEdges(edges []edge) []edge {
+	// sweep along x so only edges with overlapping x ranges are compared
+	order := make([]int, len(edges))
+	minX := make([]float64, len(edges))
+	maxX := make([]float64, len(edges))
+	for i, e := range edges {
+		order[i] = i
+		minX[i], maxX[i] = math.Min(e.a.X, e.b.X), math.Max(e.a.X, e.b.X)
+	}
+	sort.Slice(order, func(i, j int) bool { return minX[order[i]] < minX[order[j]] })
+
+	splits := make([][]split, len(edges))
+	for oi, i := range order {
+		for _, j := range order[oi+1:] {
+			if minX[j] > maxX[i] {
+				break
+			}
+			intersect(edges, i, j, splits)
+		}
+	}
+
+	seen := map[edge]bool{}
+	var out []edge
+	for i, e := range edges {
+		s := append(splits[i], split{0, e.a}, split{1, e.b})
+		sort.Slice(s, func(x, y int) bool { return s[x].t < s[y].t })
+		for k := 1; k < len(s); k++ {
+			piece := edge{a: s[k-1].p, b: s[k].p}
+			if piece.a == piece.b {
+				continue
+			}
+			key := piece
+			if lessVector(key.b, key.a) {
+				key.a, key.b = key.b, key.a
+			}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			out = append(out, piece)
+		}
+	}
+	return out
+}
+
+// intersect records where edges i and j cross or touch each other.
+func intersect(edges []edge, i, j int, splits [][]split) {
+	e, f := edges[i], edges[j]
+	if math.Max(e.a.Y, e.b.Y) < math.Min(f.a.Y, f.b.Y) || math.Max(f.a.Y, f.b.Y) < math.Min(e.a.Y, e.b.Y) {
+		return
+	}
+	r, s := e.b.Sub(e.a), f.b.Sub(f.a)
+	q := f.a.Sub(e.a)
+	denom := r.Cross(s)
+	if math.Abs(denom) <= paramEpsilon*r.Mag()*s.Mag() {
+		// parallel: only collinear overlaps matter, split each edge at the ends of the other
+		if math.Abs(q.Cross(r)) > paramEpsilon*r.MagSq() {
+			return
+		}
+		addSplit(splits, i, e, f.a)
+		addSplit(splits, i, e, f.b)
+		addSplit(splits, j, f, e.a)
+		addSplit(splits, j, f, e.b)
+		return
+	}
+	t, u := q.Cross(s)/denom, q.Cross(r)/denom
+	if t < -paramEpsilon || t > 1+paramEpsilon || u < -paramEpsilon || u > 1+paramEpsilon {
+		return
+	}
+	// snap to an end point so both edges share the exact vertex
+	var p p5go.Vector
+	switch {
+	case t <= paramEpsilon:
+		p = e.a
+	case t >= 1-paramEpsilon:
+		p = e.b
+	case u <= paramEpsilon:
+		p = f.a
+	case u >= 1-paramEpsilon:
+		p = f.b
+	default:
+		p = e.a.Add(r.Mult(t))
+	}
+	addSplit(splits, i, e, p)
+	addSplit(splits, j, f, p)
+}
+
+// addSplit splits edge i at p if p lies strictly between its end points.
+func addSplit(splits [][]split, i int, e edge, p p5go.Vector) {
+	if p == e.a || p == e.b {
+		return
+	}
+	d := e.b.Sub(e.a)
+	t := p.Sub(e.a).Dot(d) / d.MagSq()
+	if t <= 0 || t >= 1 {
+		return
+	}
+	splits[i] = append(splits[i], split{t, p})
+}
+
+// linkEdges joins directed edges into rings. Where several edges leave a vertex the sharpest left
+// turn is taken, so rings touching at a vertex stay separate.
+func linkEdges(edges []edge) Shape {
+	out := map[p5go.Vector][]int{}
+	for i, e := range edges {
+		out[e.a] = append(out[e.a], i)
+	}
+	used := make([]bool, len(edges))
+	var s Shape
+	for start := range edges {
+		if used[start] {
+			continue
+		}
+		used[start] = true
+		ring := []p5go.Vector{edges[start].a}
+		cur := edges[start]
+		closed := false
+		for {
+			if cur.b == edges[start].a {
+				closed = true
+				break
+			}
+			ring = append(ring, cur.b)
+			dir := cur.b.Sub(cur.a)
+			next, best := -1, math.Inf(-1)
+			for _, k := range out[cur.b] {
+				if used[k] {
+					continue
+				}
+				d := edges[k].b.Sub(edges[k].a)
+				if turn := math.Atan2(dir.Cross(d), dir.Dot(d)); turn > best {
+					next, best = k, turn
+				}
+			}
+			if next < 0 {
+				break
+			}
+			used[next] = true
+			cur = edges[next]
+		}
+		if !closed {
+			continue
+		}
+		if ring = removeCollinear(ring); len(ring) >= 3 {
+			s = append(s, ring)
+		}
+	}
+	return s
+}
+
+// removeCollinear removes the vertices lying on a straight line between their neighbours.
+func removeCollinear(ring []p5go.Vector) []p5go.Vector {
+	for changed := true; changed && len(ring) >= 3; {
+		changed = false
+		out := ring[:0:0]
+		for i, p := range ring {
+			prev := ring[(i+len(ring)-1)%len(ring)]
+			next := ring[(i+1)%len(ring)]
+			a, b := p.Sub(prev), next.Sub(p)
+			if math.Abs(a.Cross(b)) <= paramEpsilon*a.Mag()*b.Mag() && a.Dot(b) > 0 {
+				changed = true
+				continue
+			}
+			out = append(out, p)
+		}
+		ring = out
+	}
+	return ring
+}
+
+func lessVector(a, b p5go.Vector) bool {
+	if a.X != b.X {
+		return a.X < b.X
+	}
+	return a.Y < b.Y
+}
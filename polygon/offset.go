@@ -0,0 +1,92 @@
+package polygon
+
+import (
+	"math"
+
+	"github.com/ryomak/p5go"
+)
+
+// offsetArcSegments is the number of segments used to approximate the round joins of a full circle.
+const offsetArcSegments = 32
+
+// Offset grows the shape by delta with round joins, or shrinks it when delta is negative.
+// The result is the shape with a band of width |delta| along its outline added or removed.
+func Offset(s Shape, delta float64) Shape {
+	if delta == 0 {
+		return Normalize(s)
+	}
+	r := math.Abs(delta)
+	var bands []band
+	for _, ring := range s {
+		for i := range ring {
+			a, b := ring[i], ring[(i+1)%len(ring)]
+			bands = append(bands, band{a: a, b: b, r: r})
+		}
+	}
+
+	rings := append(Shape{}, s...)
+	for _, bd := range bands {
+		rings = append(rings, bd.circle())
+		if bd.a != bd.b {
+			rings = append(rings, bd.rect())
+		}
+	}
+	return resolve(rings, func(p p5go.Vector) bool {
+		inS := s.Contains(p)
+		if delta > 0 && inS || delta < 0 && !inS {
+			return inS
+		}
+		for _, bd := range bands {
+			if bd.contains(p) {
+				return delta > 0
+			}
+		}
+		return inS
+	})
+}
+
+// band is the polygonal area within r of the edge from a to b: a rectangle along the edge and a
+// polygon approximating a circle around a.
+type band struct {
+	a, b p5go.Vector
+	r    float64
+}
+
+func (bd band) rect() []p5go.Vector {
+	n := p5go.Vector{X: bd.a.Y - bd.b.Y, Y: bd.b.X - bd.a.X}.SetMag(bd.r)
+	return []p5go.Vector{bd.a.Add(n), bd.a.Sub(n), bd.b.Sub(n), bd.b.Add(n)}
+}
+
+func (bd band) circle() []p5go.Vector {
+	pts := make([]p5go.Vector, offsetArcSegments)
+	for i := range pts {
+		pts[i] = bd.a.Add(p5go.FromAngle(p5go.TWO_PI*float64(i)/offsetArcSegments, bd.r))
+	}
+	return pts
+}
+
+// contains returns true if p is inside the rectangle or the circle polygon of the band.
+func (bd band) contains(p p5go.Vector) bool {
+	if d := bd.b.Sub(bd.a); d != (p5go.Vector{}) {
+		l := d.Mag()
+		v := p.Sub(bd.a)
+		if t := v.Dot(d) / l; t >= 0 && t <= l && math.Abs(d.Cross(v))/l <= bd.r {
+			return true
+		}
+	}
+	v := p.Sub(bd.a)
+	m := v.Mag()
+	if m > bd.r {
+		return false
+	}
+	step := p5go.TWO_PI / offsetArcSegments
+	if m <= bd.r*math.Cos(step/2) {
+		return true
+	}
+	// between the inscribed and the outer circle: test against the chord of the sector
+	angle := math.Mod(v.Heading()+p5go.TWO_PI, p5go.TWO_PI)
+	k := math.Floor(angle / step)
+	c0 := p5go.FromAngle(k*step, bd.r)
+	c1 := p5go.FromAngle((k+1)*step, bd.r)
+	return c1.Sub(c0).Cross(v.Sub(c0)) >= 0
+}
@@ -0,0 +1,199 @@
+// Package polygon provides boolean operations, offsetting and simplification of polygons with holes.
+package polygon
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/ryomak/p5go"
+)
+
+// Shape is a filled region made of closed rings, like a shape drawn with BeginShape and
+// BeginContour/EndContour. Input rings are combined with the even-odd rule, so holes may wind
+// either way. Shapes returned by this package have clockwise outer rings and counter-clockwise
+// holes on screen, so they also fill correctly with the nonzero rule.
+type Shape [][]p5go.Vector
+
+// Contains returns true if p is inside the shape.
+func (s Shape) Contains(p p5go.Vector) bool {
+	in := false
+	for _, ring := range s {
+		if p5go.PointInPolygon(p, ring) {
+			in = !in
+		}
+	}
+	return in
+}
+
+// Area returns the sum of the signed areas of the rings. For shapes returned by this package
+// this is the filled area, since holes have a negative area.
+func (s Shape) Area() float64 {
+	var a float64
+	for _, ring := range s {
+		a += p5go.PolygonSignedArea(ring)
+	}
+	return a
+}
+
+// Bounds returns the bounding box of the shape.
+func (s Shape) Bounds() p5go.Rectangle {
+	var pts []p5go.Vector
+	for _, ring := range s {
+		pts = append(pts, ring...)
+	}
+	return p5go.BoundingBox(pts)
+}
+
+// Outer returns the outer rings of the shape, each followed by the holes directly inside it.
+// It expects a shape returned by this package.
+func (s Shape) Outer() []Shape {
+	var outers []Shape
+	var areas []float64
+	for _, ring := range s {
+		if a := p5go.PolygonSignedArea(ring); a > 0 {
+			outers = append(outers, Shape{ring})
+			areas = append(areas, a)
+		}
+	}
+	for _, ring := range s {
+		if p5go.PolygonSignedArea(ring) > 0 {
+			continue
+		}
+		// the filled side of every ring is on its left, so a point just left of an edge is inside the outer ring
+		p := insidePoint(ring)
+		best := -1
+		for i, o := range outers {
+			if p5go.PointInPolygon(p, o[0]) && (best < 0 || areas[i] < areas[best]) {
+				best = i
+			}
+		}
+		if best >= 0 {
+			outers[best] = append(outers[best], ring)
+		}
+	}
+	return outers
+}
+
+// Draw draws the shape, with one BeginShape per outer ring and a contour per hole.
+func (s Shape) Draw(r p5go.Renderer) {
+	for _, part := range s.Outer() {
+		r.BeginShape()
+		for _, p := range part[0] {
+			r.Vertex(p.X, p.Y)
+		}
+		for _, hole := range part[1:] {
+			r.BeginContour()
+			for _, p := range hole {
+				r.Vertex(p.X, p.Y)
+			}
+			r.EndContour()
+		}
+		r.EndShape(p5go.CLOSE)
+	}
+}
+
+// DrawOutline draws every ring as a closed line, for plotters and debugging.
+func (s Shape) DrawOutline(r p5go.Renderer) {
+	for _, ring := range s {
+		p5go.DrawPolygon(r, ring)
+	}
+}
+
+// SVGPath returns the shape as SVG path data, to be used with fill-rule="evenodd" or "nonzero".
+func (s Shape) SVGPath() string {
+	var b strings.Builder
+	for _, ring := range s {
+		for i, p := range ring {
+			cmd := "L"
+			if i == 0 {
+				cmd = "M"
+			}
+			fmt.Fprintf(&b, "%s%g %g ", cmd, p.X, p.Y)
+		}
+		if len(ring) > 0 {
+			b.WriteString("Z ")
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// Simplify removes vertices closer than tolerance to the outline, using the Douglas-Peucker
+// algorithm. Rings that collapse are dropped.
+func Simplify(s Shape, tolerance float64) Shape {
+	var out Shape
+	for _, ring := range s {
+		if r := SimplifyRing(ring, tolerance); len(r) >= 3 {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// SimplifyRing simplifies a closed ring with the Douglas-Peucker algorithm.
+func SimplifyRing(ring []p5go.Vector, tolerance float64) []p5go.Vector {
+	if len(ring) < 4 {
+		return ring
+	}
+	// split the ring at the vertex farthest from the first one and simplify both halves
+	far, d := 0, -1.0
+	for i, p := range ring {
+		if m := p.Sub(ring[0]).MagSq(); m > d {
+			far, d = i, m
+		}
+	}
+	closed := append(append([]p5go.Vector{}, ring...), ring[0])
+	a := SimplifyPath(closed[:far+1], tolerance)
+	b := SimplifyPath(closed[far:], tolerance)
+	return append(a[:len(a)-1], b[:len(b)-1]...)
+}
+
+// SimplifyPath simplifies an open polyline with the Douglas-Peucker algorithm, keeping both ends.
+func SimplifyPath(points []p5go.Vector, tolerance float64) []p5go.Vector {
+	if len(points) < 3 {
+		return append([]p5go.Vector{}, points...)
+	}
+	keep := make([]bool, len(points))
+	keep[0], keep[len(points)-1] = true, true
+	simplifyRange(points, 0, len(points)-1, tolerance, keep)
+	var out []p5go.Vector
+	for i, p := range points {
+		if keep[i] {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func simplifyRange(points []p5go.Vector, first, last int, tolerance float64, keep []bool) {
+	if last-first < 2 {
+		return
+	}
+	seg := p5go.Line{Start: points[first], End: points[last]}
+	idx, d := -1, tolerance
+	for i := first + 1; i < last; i++ {
+		if di := seg.Distance(points[i]); di > d {
+			idx, d = i, di
+		}
+	}
+	if idx < 0 {
+		return
+	}
+	keep[idx] = true
+	simplifyRange(points, first, idx, tolerance, keep)
+	simplifyRange(points, idx, last, tolerance, keep)
+}
+
+// insidePoint returns a point just left of the longest edge of the ring.
+func insidePoint(ring []p5go.Vector) p5go.Vector {
+	best, d := 0, -1.0
+	for i := range ring {
+		if m := ring[(i+1)%len(ring)].Sub(ring[i]).MagSq(); m > d {
+			best, d = i, m
+		}
+	}
+	a, b := ring[best], ring[(best+1)%len(ring)]
+	dir := b.Sub(a)
+	n := p5go.Vector{X: -dir.Y, Y: dir.X}.Normalize()
+	return a.Lerp(b, 0.5).Add(n.Mult(math.Max(1e-9, math.Sqrt(d)*1e-6)))
+}
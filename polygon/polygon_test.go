@@ -0,0 +1,186 @@
+package polygon
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ryomak/p5go"
+)
+
+func square(x, y, size float64) []p5go.Vector {
+	return []p5go.Vector{{X: x, Y: y}, {X: x + size, Y: y}, {X: x + size, Y: y + size}, {X: x, Y: y + size}}
+}
+
+func TestBoolean(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Shape
+		// areas of the union, intersection, difference and xor
+		union, intersection, difference, xor float64
+		// number of rings of the union
+		unionRings int
+	}{
+		{
+			name: "overlapping squares",
+			a:    Shape{square(0, 0, 10)}, b: Shape{square(5, 5, 10)},
+			union: 175, intersection: 25, difference: 75, xor: 150,
+			unionRings: 1,
+		},
+		{
+			name: "square with a hole",
+			a:    Shape{square(0, 0, 20), square(5, 5, 10)}, b: Shape{square(10, 10, 20)},
+			union: 625, intersection: 75, difference: 225, xor: 550,
+			unionRings: 2,
+		},
+		{
+			name: "touching edges",
+			a:    Shape{square(0, 0, 10)}, b: Shape{square(10, 0, 10)},
+			union: 200, intersection: 0, difference: 100, xor: 200,
+			unionRings: 1,
+		},
+		{
+			name: "touching corners",
+			a:    Shape{square(0, 0, 10)}, b: Shape{square(10, 10, 10)},
+			union: 200, intersection: 0, difference: 100, xor: 200,
+			unionRings: 2,
+		},
+		{
+			name: "collinear edges",
+			a:    Shape{square(0, 0, 10)}, b: Shape{square(5, 0, 10)},
+			union: 150, intersection: 50, difference: 50, xor: 100,
+			unionRings: 1,
+		},
+		{
+			name: "identical squares",
+			a:    Shape{square(0, 0, 10)}, b: Shape{square(0, 0, 10)},
+			union: 100, intersection: 100, difference: 0, xor: 0,
+			unionRings: 1,
+		},
+		{
+			name: "contained square",
+			a:    Shape{square(0, 0, 10)}, b: Shape{square(2, 2, 4)},
+			union: 100, intersection: 16, difference: 84, xor: 84,
+			unionRings: 1,
+		},
+		{
+			name: "disjoint squares",
+			a:    Shape{square(0, 0, 10)}, b: Shape{square(20, 0, 10)},
+			union: 200, intersection: 0, difference: 100, xor: 200,
+			unionRings: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ops := []struct {
+				op   Op
+				want float64
+			}{
+				{OpUnion, tt.union},
+				{OpIntersection, tt.intersection},
+				{OpDifference, tt.difference},
+				{OpXor, tt.xor},
+			}
+			for _, o := range ops {
+				got := Boolean(o.op, tt.a, tt.b)
+				if a := got.Area(); math.Abs(a-o.want) > 1e-9 {
+					t.Errorf("op %v: area = %v, want %v", o.op, a, o.want)
+				}
+				checkOrientation(t, got)
+				// the result covers the points inside the expected region and no others
+				for _, p := range samplePoints(tt.a, tt.b) {
+					inA, inB := tt.a.Contains(p), tt.b.Contains(p)
+					want := map[Op]bool{
+						OpUnion:        inA || inB,
+						OpIntersection: inA && inB,
+						OpDifference:   inA && !inB,
+						OpXor:          inA != inB,
+					}[o.op]
+					if got.Contains(p) != want {
+						t.Errorf("op %v: Contains(%v) = %v, want %v", o.op, p, !want, want)
+					}
+				}
+			}
+			if n := len(Union(tt.a, tt.b)); n != tt.unionRings {
+				t.Errorf("union has %d rings, want %d", n, tt.unionRings)
+			}
+		})
+	}
+}
+
+// samplePoints returns points at the centers of a fine grid over both shapes, away from their edges.
+func samplePoints(a, b Shape) []p5go.Vector {
+	bounds := a.Bounds().Union(b.Bounds())
+	var points []p5go.Vector
+	for y := bounds.Position.Y + 0.25; y < bounds.Max().Y; y += 0.5 {
+		for x := bounds.Position.X + 0.25; x < bounds.Max().X; x += 0.5 {
+			points = append(points, p5go.Vector{X: x, Y: y})
+		}
+	}
+	return points
+}
+
+// checkOrientation checks that outer rings are clockwise on screen and holes counter-clockwise,
+// so every outer ring encloses its holes.
+func checkOrientation(t *testing.T, s Shape) {
+	t.Helper()
+	for _, outer := range s.Outer() {
+		if p5go.PolygonSignedArea(outer[0]) <= 0 {
+			t.Errorf("outer ring has a non-positive area")
+		}
+		for _, hole := range outer[1:] {
+			if p5go.PolygonSignedArea(hole) >= 0 {
+				t.Errorf("hole has a non-negative area")
+			}
+		}
+	}
+}
+
+func TestOffset(t *testing.T) {
+	// the round joins are 32-gons inscribed in the circle, so grown corners lose a little area
+	circle := 0.5 * offsetArcSegments * math.Sin(2*math.Pi/offsetArcSegments)
+	tests := []struct {
+		name  string
+		shape Shape
+		delta float64
+		area  float64
+		rings int
+	}{
+		{"outset", Shape{square(0, 0, 10)}, 2, 100 + 4*10*2 + circle*4, 1},
+		{"inset", Shape{square(0, 0, 10)}, -2, 36, 1},
+		{"inset to a point", Shape{square(0, 0, 10)}, -5, 0, 0},
+		{"inset past empty", Shape{square(0, 0, 10)}, -6, 0, 0},
+		{"inset with a hole", Shape{square(0, 0, 20), square(8, 8, 4)}, -2, 16*16 - (4*4 + 4*4*2 + circle*4), 2},
+		{"outset closes a hole", Shape{square(0, 0, 20), square(8, 8, 4)}, 3, 20*20 + 4*20*3 + circle*9, 1},
+		{"zero", Shape{square(0, 0, 10)}, 0, 100, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Offset(tt.shape, tt.delta)
+			if a := got.Area(); math.Abs(a-tt.area) > 1e-6 {
+				t.Errorf("area = %v, want %v", a, tt.area)
+			}
+			if len(got) != tt.rings {
+				t.Errorf("%d rings, want %d", len(got), tt.rings)
+			}
+			checkOrientation(t, got)
+		})
+	}
+}
+
+func TestSimplify(t *testing.T) {
+	// a square with extra points on its edges and a small bump
+	ring := []p5go.Vector{
+		{X: 0, Y: 0}, {X: 5, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 5}, {X: 10.05, Y: 6}, {X: 10, Y: 7},
+		{X: 10, Y: 10}, {X: 5, Y: 10}, {X: 0, Y: 10},
+	}
+	if got := SimplifyRing(ring, 0.1); len(got) != 4 {
+		t.Errorf("SimplifyRing kept %d points, want 4: %v", len(got), got)
+	}
+	if got := SimplifyRing(ring, 0.01); len(got) != 7 {
+		t.Errorf("SimplifyRing below the bump kept %d points, want 7: %v", len(got), got)
+	}
+	path := []p5go.Vector{{X: 0, Y: 0}, {X: 1, Y: 0.01}, {X: 2, Y: 0}, {X: 3, Y: 5}}
+	if got := SimplifyPath(path, 0.1); len(got) != 3 {
+		t.Errorf("SimplifyPath kept %d points, want 3: %v", len(got), got)
+	}
+}
@@ -0,0 +1,169 @@
+package p5go
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+)
+
+// InputState is a snapshot of the input state read by the Canvas getters such as MouseX and Key.
+type InputState struct {
+	MouseX         float64        `json:"mouseX"`
+	MouseY         float64        `json:"mouseY"`
+	PMouseX        float64        `json:"pmouseX"`
+	PMouseY        float64        `json:"pmouseY"`
+	MovedX         float64        `json:"movedX"`
+	MovedY         float64        `json:"movedY"`
+	MouseButton    MouseButton    `json:"mouseButton,omitempty"`
+	MouseIsPressed bool           `json:"mouseIsPressed,omitempty"`
+	Key            string         `json:"key,omitempty"`
+	KeyCode        KeyCode        `json:"keyCode,omitempty"`
+	KeyIsPressed   bool           `json:"keyIsPressed,omitempty"`
+	KeysDown       []KeyCode      `json:"keysDown,omitempty"`
+	Touches        []PointerEvent `json:"touches,omitempty"`
+	WindowWidth    float64        `json:"windowWidth"`
+	WindowHeight   float64        `json:"windowHeight"`
+	Time           float64        `json:"time"`      // milliseconds on the performance.now clock
+	Millis         float64        `json:"millis"`    // milliseconds since the sketch started
+	DeltaTime      float64        `json:"deltaTime"` // milliseconds since the previous frame
+}
+
+// InputEvent is an input event delivered to a handler, with the input state at the time it happened.
+// Type is the p5.js method name (e.g. "mousePressed") or the DOM event name (e.g. "pointerdown").
+type InputEvent struct {
+	Type    string        `json:"type"`
+	State   InputState    `json:"state"`
+	Mouse   *MouseEvent   `json:"mouse,omitempty"`
+	Wheel   *WheelEvent   `json:"wheel,omitempty"`
+	Key     *KeyEvent     `json:"key,omitempty"`
+	Pointer *PointerEvent `json:"pointer,omitempty"`
+}
+
+// InputFrame holds the events delivered before a frame and the input state when the frame was drawn.
+type InputFrame struct {
+	Frame  int          `json:"frame"`
+	State  InputState   `json:"state"`
+	Events []InputEvent `json:"events,omitempty"`
+}
+
+// Recording is a frame by frame record of the input of a sketch.
+type Recording struct {
+	Seed   int64        `json:"seed"`
+	Width  float64      `json:"width"`
+	Height float64      `json:"height"`
+	Frames []InputFrame `json:"frames"`
+}
+
+// LoadRecording decodes a Recording from JSON.
+func LoadRecording(r io.Reader) (*Recording, error) {
+	var rec Recording
+	if err := json.NewDecoder(r).Decode(&rec); err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
+
+// ReadRecording reads a Recording from a JSON file.
+func ReadRecording(path string) (*Recording, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return LoadRecording(f)
+}
+
+// Save encodes the recording as JSON.
+func (r *Recording) Save(w io.Writer) error {
+	return json.NewEncoder(w).Encode(r)
+}
+
+// WriteFile writes the recording to a JSON file.
+func (r *Recording) WriteFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := r.Save(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Recorder collects input events and closes them into frames.
+type Recorder struct {
+	rec     *Recording
+	pending []InputEvent
+}
+
+// NewRecorder creates a Recorder for a sketch seeded with seed.
+func NewRecorder(seed int64) *Recorder {
+	return &Recorder{rec: &Recording{Seed: seed}}
+}
+
+// Event records an event delivered since the last frame.
+func (r *Recorder) Event(e InputEvent) {
+	r.pending = append(r.pending, e)
+}
+
+// Frame closes the current frame with the input state it is drawn with.
+func (r *Recorder) Frame(frame int, state InputState) {
+	r.rec.Frames = append(r.rec.Frames, InputFrame{
+		Frame:  frame,
+		State:  state,
+		Events: r.pending,
+	})
+	r.pending = nil
+}
+
+// Recording returns the frames recorded so far.
+func (r *Recorder) Recording() *Recording {
+	return r.rec
+}
+
+// Player steps through the frames of a Recording.
+type Player struct {
+	rec  *Recording
+	next int
+}
+
+// NewPlayer creates a Player positioned before the first frame of rec.
+func NewPlayer(rec *Recording) *Player {
+	return &Player{rec: rec}
+}
+
+// Next returns the next frame, or false once every frame has been played.
+func (p *Player) Next() (InputFrame, bool) {
+	if p.Done() {
+		return InputFrame{}, false
+	}
+	f := p.rec.Frames[p.next]
+	p.next++
+	return f, true
+}
+
+// Step plays the next frame: handle is called with each event recorded before the frame, in order,
+// then the input state the frame was drawn with is returned. It returns false once every frame has
+// been played. Replay runs it before every frame of a browser sketch; native code, which has no
+// Canvas, can call it the same way to feed a recording to the input logic of a sketch.
+func (p *Player) Step(handle func(e InputEvent)) (InputState, bool) {
+	f, ok := p.Next()
+	if !ok {
+		return InputState{}, false
+	}
+	for _, e := range f.Events {
+		handle(e)
+	}
+	return f.State, true
+}
+
+// Done returns true once every frame has been played.
+func (p *Player) Done() bool {
+	return p.next >= len(p.rec.Frames)
+}
+
+// Recording returns the recording being played.
+func (p *Player) Recording() *Recording {
+	return p.rec
+}
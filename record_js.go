@@ -0,0 +1,175 @@
+//go:build js && wasm
+
+package p5go
+
+import (
+	"bytes"
+	"sort"
+	"strconv"
+	"syscall/js"
+)
+
+// Record records the input of the sketch frame by frame.
+// The p5.js random and noise generators are seeded with seed so the recording can be replayed exactly.
+func Record(seed int64) Func {
+	return func(c *Canvas) {
+		c.recorder = NewRecorder(seed)
+		c.RandomSeed(seed)
+		c.NoiseSeed(seed)
+	}
+}
+
+// Replay replays a recording instead of the live input.
+// Each frame, the recorded events are passed to the handlers and the getters such as MouseX, Key,
+// DeltaTime and Millis return the recorded state. Live input resumes once the recording ends.
+func Replay(rec *Recording) Func {
+	return func(c *Canvas) {
+		c.player = NewPlayer(rec)
+		c.RandomSeed(rec.Seed)
+		c.NoiseSeed(rec.Seed)
+	}
+}
+
+// Recording returns the input recorded so far, or nil if the sketch is not recording.
+func (c *Canvas) Recording() *Recording {
+	if c.recorder == nil {
+		return nil
+	}
+	rec := c.recorder.Recording()
+	rec.Width, rec.Height = c.Width(), c.Height()
+	return rec
+}
+
+// SaveRecording downloads the input recorded so far as a JSON file.
+func (c *Canvas) SaveRecording(filename string) error {
+	rec := c.Recording()
+	if rec == nil {
+		return nil
+	}
+	var buf bytes.Buffer
+	if err := rec.Save(&buf); err != nil {
+		return err
+	}
+	c.p5Instance.Call("saveJSON", global.Get("JSON").Call("parse", buf.String()), filename)
+	return nil
+}
+
+// IsReplaying returns true while a recording is being replayed.
+func (c *Canvas) IsReplaying() bool {
+	return c.player != nil
+}
+
+// handleInput registers handler for an input event. For p5.js methods the handler replaces the previous one,
+// DOM events on the container may have several handlers. build converts the native event arguments.
+func (c *Canvas) handleInput(name string, dom bool, build func(args []js.Value) InputEvent, handler func(e InputEvent) any) {
+	if !dom {
+		c.inputHandlers[name] = []func(InputEvent) any{handler}
+		c.funcHandlers[name] = js.FuncOf(func(value js.Value, args []js.Value) any {
+			return c.dispatchInput(build(args))
+		})
+		return
+	}
+	if len(c.inputHandlers[name]) == 0 {
+		c.domHandlers[name] = append(c.domHandlers[name], js.FuncOf(func(value js.Value, args []js.Value) any {
+			return c.dispatchInput(build(args))
+		}))
+	}
+	c.inputHandlers[name] = append(c.inputHandlers[name], handler)
+}
+
+// dispatchInput records a live event and passes it to its handlers.
+// Live events are dropped while a recording is being replayed.
+func (c *Canvas) dispatchInput(e InputEvent) any {
+	if c.player != nil {
+		return nil
+	}
+	if c.recorder != nil {
+		c.recorder.Event(e)
+	}
+	return c.callInput(e)
+}
+
+func (c *Canvas) callInput(e InputEvent) any {
+	var res any
+	for _, h := range c.inputHandlers[e.Type] {
+		if r := h(e); r != nil {
+			res = r
+		}
+	}
+	if c.scenes != nil {
+		if r := c.scenes.input(c, e); r != nil {
+			res = r
+		}
+	}
+	return res
+}
+
+// stepInput runs before every frame: it closes the recorded frame or feeds the next replayed one.
+func (c *Canvas) stepInput() {
+	if c.recorder != nil {
+		c.recorder.Frame(c.FrameCount(), c.inputState())
+	}
+	if c.player == nil {
+		return
+	}
+	state, ok := c.player.Step(func(e InputEvent) {
+		c.replayState = &e.State
+		c.callInput(e)
+	})
+	if !ok {
+		c.player = nil
+		c.replayState = nil
+		return
+	}
+	c.replayState = &state
+}
+
+// inputState reads the live input state from p5.js.
+func (c *Canvas) inputState() InputState {
+	p := c.p5Instance
+	s := InputState{
+		MouseX:         p.Get("mouseX").Float(),
+		MouseY:         p.Get("mouseY").Float(),
+		PMouseX:        p.Get("pmouseX").Float(),
+		PMouseY:        p.Get("pmouseY").Float(),
+		MovedX:         p.Get("movedX").Float(),
+		MovedY:         p.Get("movedY").Float(),
+		MouseIsPressed: p.Get("mouseIsPressed").Truthy(),
+		KeyIsPressed:   p.Get("keyIsPressed").Truthy(),
+		WindowWidth:    p.Get("windowWidth").Float(),
+		WindowHeight:   p.Get("windowHeight").Float(),
+		Time:           global.Get("performance").Call("now").Float(),
+		Millis:         p.Call("millis").Float(),
+		DeltaTime:      p.Get("deltaTime").Float(),
+	}
+	if b := p.Get("mouseButton"); b.Type() == js.TypeString {
+		s.MouseButton = MouseButton(b.String())
+	}
+	if k := p.Get("key"); k.Type() == js.TypeString {
+		s.Key = k.String()
+	}
+	if k := p.Get("keyCode"); k.Type() == js.TypeNumber {
+		s.KeyCode = KeyCode(k.Int())
+	}
+	// p5.js keeps the held keys in the private _downKeys map used by keyIsDown
+	if down := p.Get("_downKeys"); down.Type() == js.TypeObject {
+		keys := global.Get("Object").Call("keys", down)
+		for i := 0; i < keys.Length(); i++ {
+			name := keys.Index(i).String()
+			if code, err := strconv.Atoi(name); err == nil && down.Get(name).Truthy() {
+				s.KeysDown = append(s.KeysDown, KeyCode(code))
+			}
+		}
+		sort.Slice(s.KeysDown, func(i, j int) bool { return s.KeysDown[i] < s.KeysDown[j] })
+	}
+	s.Touches = c.liveTouches()
+	return s
+}
+
+// event creates an InputEvent of the given type with the current input state.
+func (c *Canvas) event(typ string) InputEvent {
+	if c.replayState != nil {
+		return InputEvent{Type: typ, State: *c.replayState}
+	}
+	return InputEvent{Type: typ, State: c.inputState()}
+}
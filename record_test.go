@@ -0,0 +1,83 @@
+package p5go
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestRecordingRoundTrip(t *testing.T) {
+	states := []InputState{
+		{MouseX: 10, MouseY: 20, WindowWidth: 800, WindowHeight: 600, Time: 16},
+		{
+			MouseX: 12, MouseY: 24, PMouseX: 10, PMouseY: 20, MovedX: 2, MovedY: 4,
+			MouseButton: MouseButtonLeft, MouseIsPressed: true,
+			Key: "a", KeyCode: 65, KeyIsPressed: true, KeysDown: []KeyCode{SHIFT, 65},
+			WindowWidth: 800, WindowHeight: 600, Time: 33,
+		},
+		{
+			MouseX: 12, MouseY: 24,
+			Touches: []PointerEvent{
+				{ID: 1, Type: PointerTouch, X: 100, Y: 110, Pressure: 0.5},
+				{ID: 2, Type: PointerTouch, X: 200, Y: 210, Pressure: 0.7},
+			},
+			WindowWidth: 1024, WindowHeight: 768, Time: 50,
+		},
+	}
+	events := [][]InputEvent{
+		nil,
+		{
+			{Type: "mousePressed", State: states[1], Mouse: &MouseEvent{PointerEvent: PointerEvent{Type: PointerMouse, X: 12, Y: 24, Button: MouseButtonLeft}, Pressed: true}},
+			{Type: "keyPressed", State: states[1], Key: &KeyEvent{Key: "a", Code: 65, Shift: true}},
+		},
+		{
+			{Type: "mouseWheel", State: states[2], Wheel: &WheelEvent{X: 12, Y: 24, DeltaY: -120, Ctrl: true}},
+			{Type: "pointerdown", State: states[2], Pointer: &states[2].Touches[1]},
+		},
+	}
+
+	r := NewRecorder(42)
+	for i, s := range states {
+		for _, e := range events[i] {
+			r.Event(e)
+		}
+		r.Frame(i+1, s)
+	}
+	rec := r.Recording()
+	rec.Width, rec.Height = 800, 600
+
+	var buf bytes.Buffer
+	if err := rec.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadRecording(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Seed != 42 || loaded.Width != 800 || loaded.Height != 600 {
+		t.Errorf("header = seed %d, %vx%v", loaded.Seed, loaded.Width, loaded.Height)
+	}
+
+	p := NewPlayer(loaded)
+	for i := range states {
+		var got []InputEvent
+		state, ok := p.Step(func(e InputEvent) { got = append(got, e) })
+		if !ok {
+			t.Fatalf("frame %d: player ended early", i)
+		}
+		if !reflect.DeepEqual(state, states[i]) {
+			t.Errorf("frame %d: state = %+v, want %+v", i, state, states[i])
+		}
+		if len(got) != len(events[i]) {
+			t.Fatalf("frame %d: %d events, want %d", i, len(got), len(events[i]))
+		}
+		for j := range got {
+			if !reflect.DeepEqual(got[j], events[i][j]) {
+				t.Errorf("frame %d event %d = %+v, want %+v", i, j, got[j], events[i][j])
+			}
+		}
+	}
+	if _, ok := p.Step(func(InputEvent) { t.Error("event after the last frame") }); ok || !p.Done() {
+		t.Errorf("player did not end after the last frame")
+	}
+}
@@ -0,0 +1,35 @@
+package p5go
+
+// Renderer is the set of drawing calls used by the shape builders and the drawing helpers of the
+// sub packages. Canvas implements it; alternative backends such as an SVG or plotter writer can
+// implement it to receive the same calls.
+type Renderer interface {
+	Push()
+	Pop()
+	Translate(x, y float64, z ...float64)
+	Rotate(angle float64)
+	Scale(s float64)
+
+	Fill(args ...any)
+	Stroke(args ...any)
+	NoFill()
+	NoStroke()
+	StrokeWeight(weight float64)
+
+	Point(x, y float64, z ...float64)
+	Line(x1, y1, x2, y2 float64)
+	Rect(x, y, w, h float64)
+	Ellipse(x, y, w, h float64)
+	Circle(x, y, d float64)
+	Triangle(x1, y1, x2, y2, x3, y3 float64)
+
+	BeginShape(kind ...ShapeType)
+	Vertex(x, y float64, z ...float64)
+	EndShape(mode ...ShapeType)
+	BeginContour()
+	EndContour()
+}
+
+// CircleBatchStride is the number of values per circle in the data passed to Canvas.Circles:
+// x, y, diameter and the red, green, blue and alpha fill components.
+const CircleBatchStride = 7
@@ -0,0 +1,49 @@
+package p5go
+
+import (
+	"math"
+)
+
+// ResizeMode represents how the canvas follows the size of its container
+type ResizeMode string
+
+const (
+	// Resize modes
+	ResizeFixed    ResizeMode = "fixed"    // keep the canvas size
+	ResizeFill     ResizeMode = "fill"     // resize the canvas to fill the container
+	ResizeFit      ResizeMode = "fit"      // scale the canvas to fit the container, letterboxed to keep its aspect ratio
+	ResizePixelArt ResizeMode = "pixelart" // like ResizeFit, scaled by an integer factor without smoothing
+)
+
+// Layout is the placement of the canvas inside its container
+type Layout struct {
+	Width, Height               float64 // canvas size in sketch coordinates
+	DisplayWidth, DisplayHeight float64 // size of the canvas element on the page
+	OffsetX, OffsetY            float64 // letterbox margins
+	Scale                       float64 // display pixels per sketch pixel
+}
+
+// FitLayout computes the layout of a width x height canvas in a container of the available size.
+func FitLayout(mode ResizeMode, width, height, availWidth, availHeight float64) Layout {
+	l := Layout{Width: width, Height: height, Scale: 1}
+	switch mode {
+	case ResizeFill:
+		l.Width, l.Height = math.Floor(availWidth), math.Floor(availHeight)
+	case ResizeFit, ResizePixelArt:
+		if width <= 0 || height <= 0 {
+			break
+		}
+		l.Scale = math.Min(availWidth/width, availHeight/height)
+		if mode == ResizePixelArt {
+			l.Scale = math.Max(1, math.Floor(l.Scale))
+		}
+	}
+	l.DisplayWidth = l.Width * l.Scale
+	l.DisplayHeight = l.Height * l.Scale
+	l.OffsetX = math.Max(0, math.Floor((availWidth-l.DisplayWidth)/2))
+	l.OffsetY = math.Max(0, math.Floor((availHeight-l.DisplayHeight)/2))
+	if mode == ResizeFixed || mode == ResizeFill {
+		l.OffsetX, l.OffsetY = 0, 0
+	}
+	return l
+}
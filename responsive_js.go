@@ -0,0 +1,102 @@
+//go:build js && wasm
+
+package p5go
+
+import (
+	"fmt"
+	"math"
+	"syscall/js"
+)
+
+type responsive struct {
+	mode          ResizeMode
+	width, height float64 // logical canvas size, read from the canvas on the first layout
+}
+
+// WindowResized sets the windowResized handler for the canvas.
+// With Responsive, the canvas is laid out again before the handler is called.
+func WindowResized(handler func(c *Canvas)) Func {
+	return func(c *Canvas) {
+		c.handleInput("windowResized", false, func(args []js.Value) InputEvent {
+			return c.event("windowResized")
+		}, func(e InputEvent) any {
+			c.layout()
+			handler(c)
+			return nil
+		})
+	}
+}
+
+// Responsive makes the canvas follow the size of its container.
+// The canvas size created in setup is the logical size used by ResizeFit and ResizePixelArt.
+func Responsive(mode ResizeMode) Func {
+	return func(c *Canvas) {
+		c.responsive = &responsive{mode: mode}
+		c.p5Instance.Call("registerMethod", "pre", js.FuncOf(func(value js.Value, args []js.Value) any {
+			if c.responsive.width == 0 {
+				c.layout()
+			}
+			return nil
+		}))
+		// window resizes are laid out by the windowResized handler, so make sure there is one
+		if _, ok := c.funcHandlers["windowResized"]; !ok {
+			WindowResized(func(c *Canvas) {})(c)
+		}
+	}
+}
+
+// ResizeCanvas resizes the canvas to the specified width and height.
+func (c *Canvas) ResizeCanvas(w, h int) {
+	c.p5Instance.Call("resizeCanvas", w, h)
+}
+
+// layout places the canvas in its container according to the responsive mode.
+func (c *Canvas) layout() {
+	r := c.responsive
+	el := c.p5Instance.Get("canvas")
+	if r == nil || !el.Truthy() {
+		return
+	}
+	if r.width == 0 {
+		r.width, r.height = c.Width(), c.Height()
+	}
+
+	availWidth, availHeight := c.availableSize()
+	l := FitLayout(r.mode, r.width, r.height, availWidth, availHeight)
+	if w, h := int(l.Width), int(l.Height); w != int(c.Width()) || h != int(c.Height()) {
+		c.ResizeCanvas(w, h)
+	}
+
+	style := el.Get("style")
+	style.Set("display", "block")
+	style.Set("width", fmt.Sprintf("%dpx", int(math.Round(l.DisplayWidth))))
+	style.Set("height", fmt.Sprintf("%dpx", int(math.Round(l.DisplayHeight))))
+	style.Set("marginLeft", fmt.Sprintf("%dpx", int(l.OffsetX)))
+	style.Set("marginTop", fmt.Sprintf("%dpx", int(l.OffsetY)))
+	if r.mode == ResizePixelArt {
+		style.Set("imageRendering", "pixelated")
+	}
+}
+
+// availableSize returns the size of the container, or of the window when the container has no size.
+// The canvas is hidden while measuring so an auto sized container does not report the canvas size.
+// While a recording is replayed, the recorded window size is used so the layout does not depend on the
+// live page.
+func (c *Canvas) availableSize() (float64, float64) {
+	if s := c.replayState; s != nil {
+		return s.WindowWidth, s.WindowHeight
+	}
+	style := c.p5Instance.Get("canvas").Get("style")
+	display := style.Get("display")
+	style.Set("display", "none")
+	w := c.container.Get("clientWidth").Float()
+	h := c.container.Get("clientHeight").Float()
+	style.Set("display", display)
+	if w == 0 {
+		w = c.WindowWidth()
+	}
+	if h == 0 {
+		h = c.WindowHeight()
+	}
+	return w, h
+}
@@ -0,0 +1,109 @@
+// Package sampling generates point distributions: blue noise, jittered grids, low-discrepancy
+// sequences and weighted samples inside polygons. The random functions take a *rand.Rand, such as
+// Canvas.Rand, so a seeded sketch gets the same points on every run.
+package sampling
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/ryomak/p5go"
+)
+
+// DefaultAttempts is the number of candidates tried around each point by the Poisson-disk samplers.
+const DefaultAttempts = 30
+
+// PoissonDisk returns points inside bounds that are at least radius apart, using Bridson's algorithm.
+// attempts is the number of candidates tried around each point; 0 uses DefaultAttempts.
+func PoissonDisk(rng *rand.Rand, bounds p5go.Rectangle, radius float64, attempts int) []p5go.Vector {
+	return PoissonDiskVariable(rng, bounds, func(p5go.Vector) float64 { return radius }, radius, attempts)
+}
+
+// PoissonDiskVariable is PoissonDisk with a spacing that depends on the position, such as a noise
+// or image brightness lookup. radius must return values between 0 and maxRadius; a new point is kept
+// if no other point is closer than the radius at its position.
+func PoissonDiskVariable(rng *rand.Rand, bounds p5go.Rectangle, radius func(p p5go.Vector) float64, maxRadius float64, attempts int) []p5go.Vector {
+	if maxRadius <= 0 {
+		return nil
+	}
+	if attempts <= 0 {
+		attempts = DefaultAttempts
+	}
+	bounds = bounds.Bounds()
+	lo := bounds.Min()
+	cols := int(math.Ceil(bounds.Size.X/maxRadius)) + 1
+	rows := int(math.Ceil(bounds.Size.Y/maxRadius)) + 1
+	grid := make([][]int, cols*rows)
+	cellOf := func(p p5go.Vector) (int, int) {
+		return int((p.X - lo.X) / maxRadius), int((p.Y - lo.Y) / maxRadius)
+	}
+
+	var points []p5go.Vector
+	fits := func(p p5go.Vector, r float64) bool {
+		cx, cy := cellOf(p)
+		for y := max(0, cy-1); y <= min(rows-1, cy+1); y++ {
+			for x := max(0, cx-1); x <= min(cols-1, cx+1); x++ {
+				for _, i := range grid[y*cols+x] {
+					if points[i].Sub(p).MagSq() < r*r {
+						return false
+					}
+				}
+			}
+		}
+		return true
+	}
+	add := func(p p5go.Vector) {
+		cx, cy := cellOf(p)
+		grid[cy*cols+cx] = append(grid[cy*cols+cx], len(points))
+		points = append(points, p)
+	}
+
+	add(p5go.Vector{X: lo.X + rng.Float64()*bounds.Size.X, Y: lo.Y + rng.Float64()*bounds.Size.Y})
+	active := []int{0}
+	for len(active) > 0 {
+		k := rng.Intn(len(active))
+		p := points[active[k]]
+		r := math.Min(radius(p), maxRadius)
+		found := false
+		for j := 0; j < attempts && r > 0; j++ {
+			// uniform in the annulus between r and 2r
+			d := math.Sqrt(r*r + rng.Float64()*3*r*r)
+			q := p.Add(p5go.FromAngle(rng.Float64()*p5go.TWO_PI, d))
+			if !bounds.Contains(q) {
+				continue
+			}
+			if fits(q, math.Min(radius(q), maxRadius)) {
+				add(q)
+				active = append(active, len(points)-1)
+				found = true
+				break
+			}
+		}
+		if !found {
+			active[k] = active[len(active)-1]
+			active = active[:len(active)-1]
+		}
+	}
+	return points
+}
+
+// JitteredGrid returns one point per cell of a cols x rows grid over bounds, moved randomly inside
+// its cell. jitter is the fraction of the cell the point may move over, 0 for the cell centers and
+// 1 for anywhere in the cell.
+func JitteredGrid(rng *rand.Rand, bounds p5go.Rectangle, cols, rows int, jitter float64) []p5go.Vector {
+	if cols <= 0 || rows <= 0 {
+		return nil
+	}
+	bounds = bounds.Bounds()
+	w, h := bounds.Size.X/float64(cols), bounds.Size.Y/float64(rows)
+	points := make([]p5go.Vector, 0, cols*rows)
+	for y := 0; y < rows; y++ {
+		for x := 0; x < cols; x++ {
+			points = append(points, p5go.Vector{
+				X: bounds.Position.X + (float64(x)+0.5+(rng.Float64()-0.5)*jitter)*w,
+				Y: bounds.Position.Y + (float64(y)+0.5+(rng.Float64()-0.5)*jitter)*h,
+			})
+		}
+	}
+	return points
+}
@@ -0,0 +1,33 @@
+package sampling
+
+import (
+	"math/rand"
+
+	"github.com/ryomak/p5go"
+)
+
+// maxRejections bounds the candidates tried per requested point, so degenerate polygons or weights
+// that are zero everywhere return early instead of looping forever.
+const maxRejections = 10000
+
+// PointsInPolygon returns n random points inside the polygon. weight may be nil for a uniform
+// distribution; otherwise it returns the relative density at a point, between 0 and 1, and points
+// are kept with that probability. Fewer than n points are returned if too many are rejected.
+func PointsInPolygon(rng *rand.Rand, polygon []p5go.Vector, n int, weight func(p p5go.Vector) float64) []p5go.Vector {
+	if len(polygon) < 3 || n <= 0 {
+		return nil
+	}
+	b := p5go.BoundingBox(polygon)
+	points := make([]p5go.Vector, 0, n)
+	for tries := 0; len(points) < n && tries < n*maxRejections; tries++ {
+		p := p5go.Vector{X: b.Position.X + rng.Float64()*b.Size.X, Y: b.Position.Y + rng.Float64()*b.Size.Y}
+		if !p5go.PointInPolygon(p, polygon) {
+			continue
+		}
+		if weight != nil && rng.Float64() >= weight(p) {
+			continue
+		}
+		points = append(points, p)
+	}
+	return points
+}
@@ -0,0 +1,176 @@
+package sampling
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/ryomak/p5go"
+)
+
+var bounds = p5go.Rectangle{Position: p5go.Vector{X: 10, Y: 20}, Size: p5go.Vector{X: 300, Y: 200}}
+
+func inBounds(p p5go.Vector) bool {
+	return p.X >= bounds.Position.X && p.X <= bounds.Max().X && p.Y >= bounds.Position.Y && p.Y <= bounds.Max().Y
+}
+
+func TestPoissonDisk(t *testing.T) {
+	const radius = 12
+	points := PoissonDisk(rand.New(rand.NewSource(1)), bounds, radius, 0)
+	// a maximal packing at this radius covers the bounds with far more points than this
+	if len(points) < 200 {
+		t.Fatalf("%d points, want the bounds covered", len(points))
+	}
+	for i, p := range points {
+		if !inBounds(p) {
+			t.Fatalf("point %v out of bounds", p)
+		}
+		for _, q := range points[i+1:] {
+			if d := p.Sub(q).Mag(); d < radius {
+				t.Fatalf("points %v and %v are %v apart, want at least %v", p, q, d, radius)
+			}
+		}
+	}
+	if again := PoissonDisk(rand.New(rand.NewSource(1)), bounds, radius, 0); !slices.Equal(points, again) {
+		t.Errorf("the same seed gave different points")
+	}
+	if other := PoissonDisk(rand.New(rand.NewSource(2)), bounds, radius, 0); slices.Equal(points, other) {
+		t.Errorf("another seed gave the same points")
+	}
+	if got := PoissonDisk(rand.New(rand.NewSource(1)), bounds, 0, 0); got != nil {
+		t.Errorf("a radius of 0 gave %d points", len(got))
+	}
+}
+
+func TestPoissonDiskVariable(t *testing.T) {
+	// dense on the left, sparse on the right
+	radius := func(p p5go.Vector) float64 { return 4 + 12*(p.X-bounds.Position.X)/bounds.Size.X }
+	points := PoissonDiskVariable(rand.New(rand.NewSource(3)), bounds, radius, 16, 0)
+	left, right := 0, 0
+	for i, p := range points {
+		if !inBounds(p) {
+			t.Fatalf("point %v out of bounds", p)
+		}
+		if p.X < bounds.Center().X {
+			left++
+		} else {
+			right++
+		}
+		// a point added later is at least its own radius from earlier ones
+		for _, q := range points[:i] {
+			if d := p.Sub(q).Mag(); d < radius(p)-1e-9 {
+				t.Fatalf("point %v is %v from %v, want at least %v", p, d, q, radius(p))
+			}
+		}
+	}
+	if left <= right {
+		t.Errorf("%d points on the dense half, %d on the sparse one", left, right)
+	}
+}
+
+func TestJitteredGrid(t *testing.T) {
+	points := JitteredGrid(rand.New(rand.NewSource(1)), bounds, 6, 4, 1)
+	if len(points) != 24 {
+		t.Fatalf("%d points, want 24", len(points))
+	}
+	for i, p := range points {
+		col, row := i%6, i/6
+		cell := p5go.Rectangle{Position: p5go.Vector{X: 10 + float64(col)*50, Y: 20 + float64(row)*50}, Size: p5go.Vector{X: 50, Y: 50}}
+		if !cell.Contains(p) {
+			t.Errorf("point %d at %v outside its cell %v", i, p, cell)
+		}
+	}
+	if centers := JitteredGrid(rand.New(rand.NewSource(1)), bounds, 6, 4, 0); centers[7] != (p5go.Vector{X: 85, Y: 95}) {
+		t.Errorf("point 7 without jitter at %v, want the cell center", centers[7])
+	}
+}
+
+func TestHalton(t *testing.T) {
+	tests := []struct {
+		i, base int
+		want    float64
+	}{
+		{0, 2, 0}, {1, 2, 0.5}, {2, 2, 0.25}, {3, 2, 0.75}, {4, 2, 0.125},
+		{1, 3, 1.0 / 3}, {2, 3, 2.0 / 3}, {3, 3, 1.0 / 9}, {5, 3, 7.0 / 9},
+		// bases below 2 have no sequence
+		{5, 1, 0}, {5, 0, 0}, {5, -2, 0},
+	}
+	for _, tt := range tests {
+		if got := Halton(tt.i, tt.base); math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("Halton(%d, %d) = %v, want %v", tt.i, tt.base, got, tt.want)
+		}
+	}
+}
+
+func TestSequencePoints(t *testing.T) {
+	unit := p5go.Rectangle{Size: p5go.Vector{X: 1, Y: 1}}
+	generators := map[string]func(rng *rand.Rand, bounds p5go.Rectangle, n int) []p5go.Vector{
+		"halton": HaltonPoints,
+		"sobol":  SobolPoints,
+	}
+	for name, gen := range generators {
+		// both skip the corner of the sequence
+		if first := gen(nil, unit, 1)[0]; first == (p5go.Vector{}) {
+			t.Errorf("%s: first point at the corner", name)
+		}
+		// a low-discrepancy sequence puts about the same number of points in every cell of a grid
+		points := gen(nil, bounds, 400)
+		counts := make([]int, 16)
+		for _, p := range points {
+			if !inBounds(p) {
+				t.Fatalf("%s: point %v out of bounds", name, p)
+			}
+			col := min(3, int((p.X-bounds.Position.X)/bounds.Size.X*4))
+			row := min(3, int((p.Y-bounds.Position.Y)/bounds.Size.Y*4))
+			counts[row*4+col]++
+		}
+		for i, c := range counts {
+			if c < 20 || c > 30 {
+				t.Errorf("%s: %d points in cell %d, want about 25", name, c, i)
+			}
+		}
+		if !slices.Equal(gen(rand.New(rand.NewSource(4)), bounds, 50), gen(rand.New(rand.NewSource(4)), bounds, 50)) {
+			t.Errorf("%s: the same seed gave different points", name)
+		}
+		if slices.Equal(gen(rand.New(rand.NewSource(4)), bounds, 50), gen(nil, bounds, 50)) {
+			t.Errorf("%s: the seeded points are not shifted", name)
+		}
+	}
+	if x, y := Sobol(1); x != 0.5 || y != 0.5 {
+		t.Errorf("Sobol(1) = %v, %v, want 0.5, 0.5", x, y)
+	}
+}
+
+func TestPointsInPolygon(t *testing.T) {
+	// an L shape, whose bounding box is mostly outside it
+	polygon := []p5go.Vector{{X: 0, Y: 0}, {X: 100, Y: 0}, {X: 100, Y: 10}, {X: 10, Y: 10}, {X: 10, Y: 100}, {X: 0, Y: 100}}
+	points := PointsInPolygon(rand.New(rand.NewSource(1)), polygon, 500, nil)
+	if len(points) != 500 {
+		t.Fatalf("%d points, want 500", len(points))
+	}
+	for _, p := range points {
+		if !p5go.PointInPolygon(p, polygon) {
+			t.Fatalf("point %v outside the polygon", p)
+		}
+	}
+
+	// a weight of 0 on the right arm keeps every point on the left arm
+	weighted := PointsInPolygon(rand.New(rand.NewSource(1)), polygon, 100, func(p p5go.Vector) float64 {
+		if p.X > 10 {
+			return 0
+		}
+		return 1
+	})
+	for _, p := range weighted {
+		if p.X > 10 {
+			t.Fatalf("point %v where the weight is 0", p)
+		}
+	}
+	if got := PointsInPolygon(rand.New(rand.NewSource(1)), polygon, 5, func(p5go.Vector) float64 { return 0 }); len(got) != 0 {
+		t.Errorf("%d points with a weight of 0 everywhere", len(got))
+	}
+	if got := PointsInPolygon(rand.New(rand.NewSource(1)), polygon[:2], 5, nil); got != nil {
+		t.Errorf("points in a polygon of 2 points")
+	}
+}
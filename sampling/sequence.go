@@ -0,0 +1,84 @@
+package sampling
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/ryomak/p5go"
+)
+
+// Halton returns the i-th element of the Halton sequence in the given prime base, between 0 and 1.
+// It returns 0 for a base below 2.
+func Halton(i, base int) float64 {
+	if base < 2 {
+		return 0
+	}
+	f, r := 1.0, 0.0
+	for i > 0 {
+		f /= float64(base)
+		r += f * float64(i%base)
+		i /= base
+	}
+	return r
+}
+
+// HaltonPoints returns n points of the 2D Halton sequence in bases 2 and 3 scaled to bounds.
+// With a non-nil rng the sequence is shifted by a random offset, wrapping around the bounds, which
+// gives a different set of points per seed with the same even coverage.
+func HaltonPoints(rng *rand.Rand, bounds p5go.Rectangle, n int) []p5go.Vector {
+	var ox, oy float64
+	if rng != nil {
+		ox, oy = rng.Float64(), rng.Float64()
+	}
+	points := make([]p5go.Vector, n)
+	for i := range points {
+		// skip the first element, which is 0 in every base
+		u := math.Mod(Halton(i+1, 2)+ox, 1)
+		v := math.Mod(Halton(i+1, 3)+oy, 1)
+		points[i] = scale(bounds, u, v)
+	}
+	return points
+}
+
+// Sobol returns the i-th point of the 2D Sobol sequence, with coordinates between 0 and 1.
+func Sobol(i int) (float64, float64) {
+	x, y := sobol(uint32(i))
+	return float64(x) / (1 << 32), float64(y) / (1 << 32)
+}
+
+// SobolPoints returns n points of the 2D Sobol sequence scaled to bounds, starting at its second
+// point as HaltonPoints does.
+// With a non-nil rng the sequence is scrambled with a random digital shift, which gives a different
+// set of points per seed with the same even coverage.
+func SobolPoints(rng *rand.Rand, bounds p5go.Rectangle, n int) []p5go.Vector {
+	var sx, sy uint32
+	if rng != nil {
+		sx, sy = rng.Uint32(), rng.Uint32()
+	}
+	points := make([]p5go.Vector, n)
+	for i := range points {
+		// skip the first point, which is the corner (0, 0)
+		x, y := sobol(uint32(i + 1))
+		points[i] = scale(bounds, float64(x^sx)/(1<<32), float64(y^sy)/(1<<32))
+	}
+	return points
+}
+
+// sobol returns the i-th point of the 2D Sobol sequence as 32 bit fractions. The first dimension
+// is the van der Corput sequence in base 2, the second uses the primitive polynomial x + 1.
+func sobol(i uint32) (uint32, uint32) {
+	var x, y uint32
+	v := uint32(1 << 31)
+	for bit := 0; i != 0; bit, i = bit+1, i>>1 {
+		if i&1 != 0 {
+			x ^= 1 << (31 - bit)
+			y ^= v
+		}
+		v ^= v >> 1
+	}
+	return x, y
+}
+
+func scale(bounds p5go.Rectangle, u, v float64) p5go.Vector {
+	return p5go.Vector{X: bounds.Position.X + u*bounds.Size.X, Y: bounds.Position.Y + v*bounds.Size.Y}
+}
@@ -0,0 +1,148 @@
+package p5go
+
+// sceneStack is the stack and transition bookkeeping behind Scenes. It knows nothing about the
+// canvas: entering, exiting and overlaying a scene S go through the functions it is given.
+type sceneStack[S any] struct {
+	stack   []S
+	pending bool // the first scene has not entered yet
+	active  *sceneChange[S]
+
+	enter   func(s S)
+	exit    func(s S)
+	overlay func(s S) bool
+}
+
+// sceneTransition is the part of a Transition the stack needs. draw draws progress t.
+type sceneTransition struct {
+	duration float64
+	ease     func(t float64) float64
+	draw     func(t float64)
+}
+
+// sceneChange is a transition in progress.
+type sceneChange[S any] struct {
+	sceneTransition
+	from    []S // the scenes visible before the change
+	elapsed float64
+	done    func()
+}
+
+// reset starts the stack over with first, which enters before the first frame.
+func (m *sceneStack[S]) reset(first S) {
+	m.stack = []S{first}
+	m.pending = true
+	m.active = nil
+}
+
+// flush enters the first scene if it has not entered yet.
+func (m *sceneStack[S]) flush() {
+	if !m.pending {
+		return
+	}
+	m.pending = false
+	if len(m.stack) > 0 {
+		m.enter(m.stack[0])
+	}
+}
+
+// top returns the top scene.
+func (m *sceneStack[S]) top() (S, bool) {
+	if len(m.stack) == 0 {
+		var zero S
+		return zero, false
+	}
+	return m.stack[len(m.stack)-1], true
+}
+
+// push enters s on top of the stack.
+func (m *sceneStack[S]) push(s S, transition *sceneTransition) {
+	m.flush()
+	m.finish()
+	from := m.visible()
+	m.stack = append(m.stack, s)
+	m.enter(s)
+	m.start(from, transition, nil)
+}
+
+// pop removes the top scene, which exits when the transition ends.
+func (m *sceneStack[S]) pop(transition *sceneTransition) (S, bool) {
+	m.flush()
+	m.finish()
+	top, ok := m.top()
+	if !ok {
+		return top, false
+	}
+	from := m.visible()
+	m.stack = m.stack[:len(m.stack)-1]
+	m.start(from, transition, func() { m.exit(top) })
+	return top, true
+}
+
+// replace swaps the top scene for s. The old scene exits when the transition ends.
+func (m *sceneStack[S]) replace(s S, transition *sceneTransition) {
+	m.flush()
+	m.finish()
+	from := m.visible()
+	top, ok := m.top()
+	if ok {
+		m.stack = m.stack[:len(m.stack)-1]
+	}
+	m.stack = append(m.stack, s)
+	m.enter(s)
+	m.start(from, transition, func() {
+		if ok {
+			m.exit(top)
+		}
+	})
+}
+
+// visible returns the scenes that are drawn: the top scene and the overlays' scenes below it.
+func (m *sceneStack[S]) visible() []S {
+	i := len(m.stack) - 1
+	for i > 0 && m.overlay(m.stack[i]) {
+		i--
+	}
+	return append([]S(nil), m.stack[max(i, 0):]...)
+}
+
+// finish ends the running transition at once.
+func (m *sceneStack[S]) finish() {
+	tr := m.active
+	m.active = nil
+	if tr != nil && tr.done != nil {
+		tr.done()
+	}
+}
+
+// start begins a transition from the scenes that were visible, or finishes the change at once.
+func (m *sceneStack[S]) start(from []S, transition *sceneTransition, done func()) {
+	if transition == nil || transition.duration <= 0 || transition.draw == nil {
+		if done != nil {
+			done()
+		}
+		return
+	}
+	m.active = &sceneChange[S]{sceneTransition: *transition, from: from, done: done}
+}
+
+// advance moves the running transition by dt seconds and returns it with its eased progress,
+// or nil if there is none.
+func (m *sceneStack[S]) advance(dt float64) (*sceneChange[S], float64) {
+	tr := m.active
+	if tr == nil {
+		return nil, 0
+	}
+	tr.elapsed += dt
+	t := min(1, tr.elapsed/tr.duration)
+	if tr.ease != nil {
+		t = tr.ease(t)
+	}
+	return tr, t
+}
+
+// settle ends the running transition once its duration has passed.
+func (m *sceneStack[S]) settle() {
+	if m.active != nil && m.active.elapsed >= m.active.duration {
+		m.finish()
+	}
+}
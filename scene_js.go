@@ -0,0 +1,324 @@
+//go:build js && wasm
+
+package p5go
+
+import "syscall/js"
+
+// Scene is one screen of a sketch, such as a title, game or results screen.
+// Embed BaseScene to implement only the methods a scene needs.
+type Scene interface {
+	// Enter is called when the scene is pushed or switched to, before its first Update.
+	Enter(c *Canvas)
+	// Exit is called when the scene is popped or switched away from.
+	Exit(c *Canvas)
+	// Update advances the scene by dt seconds. Only the top scene is updated.
+	Update(c *Canvas, dt float64)
+	// Draw draws the scene.
+	Draw(c *Canvas)
+
+	// The input hooks receive the events of the matching handlers. Only the top scene gets input.
+	MousePressed(c *Canvas, e MouseEvent)
+	MouseReleased(c *Canvas, e MouseReleasedEvent)
+	MouseClicked(c *Canvas, e MouseClickedEvent)
+	DoubleClicked(c *Canvas, e DoubleClickedEvent)
+	MouseDragged(c *Canvas, e MouseDraggedEvent)
+	MouseMoved(c *Canvas)
+	MouseWheel(c *Canvas, e WheelEvent) bool
+	KeyPressed(c *Canvas, e KeyEvent)
+	KeyReleased(c *Canvas, e KeyEvent)
+	KeyTyped(c *Canvas, e KeyEvent)
+	TouchStarted(c *Canvas, touches []PointerEvent) bool
+	TouchMoved(c *Canvas, touches []PointerEvent) bool
+	TouchEnded(c *Canvas, touches []PointerEvent) bool
+	PointerDown(c *Canvas, e PointerEvent)
+	PointerMove(c *Canvas, e PointerEvent)
+	PointerUp(c *Canvas, e PointerEvent)
+	PointerCancel(c *Canvas, e PointerEvent)
+}
+
+// OverlayScene is implemented by scenes drawn over the scene below them, such as a pause menu.
+// The scenes below keep being drawn but are not updated.
+type OverlayScene interface {
+	Scene
+	Overlay() bool
+}
+
+// BaseScene implements every method of Scene with no behavior.
+type BaseScene struct{}
+
+func (BaseScene) Enter(c *Canvas)                                     {}
+func (BaseScene) Exit(c *Canvas)                                      {}
+func (BaseScene) Update(c *Canvas, dt float64)                        {}
+func (BaseScene) Draw(c *Canvas)                                      {}
+func (BaseScene) MousePressed(c *Canvas, e MouseEvent)                {}
+func (BaseScene) MouseReleased(c *Canvas, e MouseReleasedEvent)       {}
+func (BaseScene) MouseClicked(c *Canvas, e MouseClickedEvent)         {}
+func (BaseScene) DoubleClicked(c *Canvas, e DoubleClickedEvent)       {}
+func (BaseScene) MouseDragged(c *Canvas, e MouseDraggedEvent)         {}
+func (BaseScene) MouseMoved(c *Canvas)                                {}
+func (BaseScene) MouseWheel(c *Canvas, e WheelEvent) bool             { return false }
+func (BaseScene) KeyPressed(c *Canvas, e KeyEvent)                    {}
+func (BaseScene) KeyReleased(c *Canvas, e KeyEvent)                   {}
+func (BaseScene) KeyTyped(c *Canvas, e KeyEvent)                      {}
+func (BaseScene) TouchStarted(c *Canvas, touches []PointerEvent) bool { return false }
+func (BaseScene) TouchMoved(c *Canvas, touches []PointerEvent) bool   { return false }
+func (BaseScene) TouchEnded(c *Canvas, touches []PointerEvent) bool   { return false }
+func (BaseScene) PointerDown(c *Canvas, e PointerEvent)               {}
+func (BaseScene) PointerMove(c *Canvas, e PointerEvent)               {}
+func (BaseScene) PointerUp(c *Canvas, e PointerEvent)                 {}
+func (BaseScene) PointerCancel(c *Canvas, e PointerEvent)             {}
+
+// Transition blends the outgoing and incoming scenes when the scene stack changes.
+// Both scenes are rendered into offscreen buffers the size of the canvas, which Draw composites.
+type Transition struct {
+	Duration float64                 // seconds
+	Ease     func(t float64) float64 // applied to the progress, linear if nil
+	// Draw draws the transition at progress t from 0 to 1.
+	Draw func(c *Canvas, from, to *Canvas, t float64)
+}
+
+// Fade cross-fades from the outgoing to the incoming scene.
+func Fade(duration float64) Transition {
+	return Transition{Duration: duration, Draw: func(c *Canvas, from, to *Canvas, t float64) {
+		c.Image(from.p5Instance, 0, 0)
+		c.p5Instance.Get("drawingContext").Set("globalAlpha", t)
+		c.Image(to.p5Instance, 0, 0)
+	}}
+}
+
+// Slide pushes the outgoing scene out of the canvas in direction, such as Vector{X: -1} for left,
+// while the incoming scene slides in behind it.
+func Slide(duration float64, direction Vector) Transition {
+	return Transition{Duration: duration, Draw: func(c *Canvas, from, to *Canvas, t float64) {
+		w, h := float64(c.Width()), float64(c.Height())
+		c.Image(from.p5Instance, direction.X*w*t, direction.Y*h*t)
+		c.Image(to.p5Instance, direction.X*w*(t-1), direction.Y*h*(t-1))
+	}}
+}
+
+// Scenes runs a stack of scenes starting with first. It takes the place of the Draw handler:
+// every frame the top scene is updated and the stack is drawn. The input handlers of the sketch
+// keep working, and the events are also passed to the top scene.
+func Scenes(first Scene) Func {
+	return func(c *Canvas) {
+		m := c.sceneManager()
+		m.reset(first)
+		c.funcHandlers["draw"] = js.FuncOf(func(value js.Value, args []js.Value) any {
+			m.frame(c)
+			return nil
+		})
+
+		// make sure every input event reaches the scenes, without replacing the handlers of the sketch
+		noMouse := func(c *Canvas, e MouseEvent) {}
+		noKey := func(c *Canvas, e KeyEvent) {}
+		noTouch := func(c *Canvas, touches []PointerEvent) bool { return false }
+		for name, f := range map[string]Func{
+			"mousePressed":  mouseHandler("mousePressed", noMouse),
+			"mouseReleased": mouseHandler("mouseReleased", noMouse),
+			"mouseClicked":  mouseHandler("mouseClicked", noMouse),
+			"doubleClicked": mouseHandler("doubleClicked", noMouse),
+			"mouseDragged":  mouseHandler("mouseDragged", noMouse),
+			"mouseMoved":    MouseMoved(func(c *Canvas) {}),
+			"mouseWheel":    MouseWheel(func(c *Canvas, e WheelEvent) bool { return false }),
+			"keyPressed":    keyHandler("keyPressed", noKey),
+			"keyReleased":   keyHandler("keyReleased", noKey),
+			"keyTyped":      keyHandler("keyTyped", noKey),
+			"touchStarted":  TouchStarted(noTouch),
+			"touchMoved":    TouchMoved(noTouch),
+			"touchEnded":    TouchEnded(noTouch),
+		} {
+			if _, ok := c.funcHandlers[name]; !ok {
+				f(c)
+			}
+		}
+		// pointer handlers are added to each other, so one is only needed when there is none yet
+		noPointer := func(c *Canvas, e PointerEvent) {}
+		for name, f := range map[string]Func{
+			"pointerdown":   PointerDown(noPointer),
+			"pointermove":   PointerMove(noPointer),
+			"pointerup":     PointerUp(noPointer),
+			"pointercancel": PointerCancel(noPointer),
+		} {
+			if len(c.inputHandlers[name]) == 0 {
+				f(c)
+			}
+		}
+	}
+}
+
+// PushScene enters s on top of the current scene, which stays on the stack.
+func (c *Canvas) PushScene(s Scene, transition ...Transition) {
+	m := c.sceneManager()
+	m.push(s, m.transition(c, transition))
+}
+
+// PopScene exits the top scene and returns to the one below it. It returns the popped scene.
+func (c *Canvas) PopScene(transition ...Transition) Scene {
+	m := c.sceneManager()
+	top, _ := m.pop(m.transition(c, transition))
+	return top
+}
+
+// SwitchScene replaces the top scene with s.
+func (c *Canvas) SwitchScene(s Scene, transition ...Transition) {
+	m := c.sceneManager()
+	m.replace(s, m.transition(c, transition))
+}
+
+// CurrentScene returns the top scene, or nil if the stack is empty.
+func (c *Canvas) CurrentScene() Scene {
+	if c.scenes == nil {
+		return nil
+	}
+	top, _ := c.scenes.top()
+	return top
+}
+
+// sceneManager holds the scene stack of a canvas.
+type sceneManager struct {
+	sceneStack[Scene]
+	from *Canvas // offscreen buffers of the transitions
+	to   *Canvas
+}
+
+func (c *Canvas) sceneManager() *sceneManager {
+	if c.scenes == nil {
+		c.scenes = &sceneManager{sceneStack: sceneStack[Scene]{
+			enter: func(s Scene) { s.Enter(c) },
+			exit:  func(s Scene) { s.Exit(c) },
+			overlay: func(s Scene) bool {
+				o, ok := s.(OverlayScene)
+				return ok && o.Overlay()
+			},
+		}}
+	}
+	return c.scenes
+}
+
+// transition converts the optional transition of a stack change for the stack.
+func (m *sceneManager) transition(c *Canvas, transition []Transition) *sceneTransition {
+	if len(transition) == 0 || transition[0].Draw == nil {
+		return nil
+	}
+	tr := transition[0]
+	return &sceneTransition{duration: tr.Duration, ease: tr.Ease, draw: func(t float64) {
+		tr.Draw(c, m.from, m.to, t)
+	}}
+}
+
+func (m *sceneManager) frame(c *Canvas) {
+	dt := c.DeltaTime() / 1000
+	m.flush()
+	if s, ok := m.top(); ok {
+		s.Update(c, dt)
+	}
+	tr, t := m.advance(dt)
+	if tr == nil {
+		drawScenes(c, m.visible())
+		return
+	}
+
+	m.buffers(c)
+	c.Clear()
+	drawScenes(c, tr.from)
+	snapshot(c, m.from)
+	c.Clear()
+	drawScenes(c, m.visible())
+	snapshot(c, m.to)
+	c.Clear()
+	c.Push()
+	tr.draw(t)
+	c.Pop()
+	m.settle()
+}
+
+// buffers creates the offscreen buffers, or recreates them when the canvas was resized.
+func (m *sceneManager) buffers(c *Canvas) {
+	w, h := c.Width(), c.Height()
+	for _, b := range []**Canvas{&m.from, &m.to} {
+		if *b != nil && (*b).Width() == w && (*b).Height() == h {
+			continue
+		}
+		if *b != nil {
+			(*b).p5Instance.Call("remove")
+		}
+		*b = c.CreateGraphics(float64(w), float64(h))
+	}
+}
+
+func drawScenes(c *Canvas, scenes []Scene) {
+	for _, s := range scenes {
+		c.Push()
+		s.Draw(c)
+		c.Pop()
+	}
+}
+
+// snapshot copies the canvas into the buffer.
+func snapshot(c *Canvas, buf *Canvas) {
+	buf.Clear()
+	buf.p5Instance.Get("drawingContext").Call("drawImage", c.p5Instance.Get("canvas"), 0, 0, c.Width(), c.Height())
+}
+
+// input passes an input event to the top scene.
+func (m *sceneManager) input(c *Canvas, e InputEvent) any {
+	m.flush()
+	s, ok := m.top()
+	if !ok {
+		return nil
+	}
+	switch {
+	case e.Mouse != nil:
+		switch e.Type {
+		case "mousePressed":
+			s.MousePressed(c, *e.Mouse)
+		case "mouseReleased":
+			s.MouseReleased(c, MouseReleasedEvent(*e.Mouse))
+		case "mouseClicked":
+			s.MouseClicked(c, MouseClickedEvent(*e.Mouse))
+		case "doubleClicked":
+			s.DoubleClicked(c, DoubleClickedEvent(*e.Mouse))
+		case "mouseDragged":
+			s.MouseDragged(c, MouseDraggedEvent(*e.Mouse))
+		}
+	case e.Wheel != nil:
+		if s.MouseWheel(c, *e.Wheel) {
+			return false
+		}
+	case e.Key != nil:
+		switch e.Type {
+		case "keyPressed":
+			s.KeyPressed(c, *e.Key)
+		case "keyReleased":
+			s.KeyReleased(c, *e.Key)
+		case "keyTyped":
+			s.KeyTyped(c, *e.Key)
+		}
+	case e.Pointer != nil:
+		switch e.Type {
+		case "pointerdown":
+			s.PointerDown(c, *e.Pointer)
+		case "pointermove":
+			s.PointerMove(c, *e.Pointer)
+		case "pointerup":
+			s.PointerUp(c, *e.Pointer)
+		case "pointercancel":
+			s.PointerCancel(c, *e.Pointer)
+		}
+	case e.Type == "mouseMoved":
+		s.MouseMoved(c)
+	case e.Type == "touchStarted":
+		if s.TouchStarted(c, e.State.Touches) {
+			return false
+		}
+	case e.Type == "touchMoved":
+		if s.TouchMoved(c, e.State.Touches) {
+			return false
+		}
+	case e.Type == "touchEnded":
+		if s.TouchEnded(c, e.State.Touches) {
+			return false
+		}
+	}
+	return nil
+}
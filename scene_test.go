@@ -0,0 +1,130 @@
+package p5go
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// testScenes returns a stack of named scenes logging every enter and exit. Scenes whose name
+// starts with "overlay" are overlays.
+func testScenes(log *[]string) *sceneStack[string] {
+	return &sceneStack[string]{
+		enter:   func(s string) { *log = append(*log, "enter "+s) },
+		exit:    func(s string) { *log = append(*log, "exit "+s) },
+		overlay: func(s string) bool { return strings.HasPrefix(s, "overlay") },
+	}
+}
+
+func TestSceneStackFirstEnter(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(m *sceneStack[string])
+		want   []string
+	}{
+		{"frame", func(m *sceneStack[string]) { m.flush() }, []string{"enter title"}},
+		{"push", func(m *sceneStack[string]) { m.push("game", nil) }, []string{"enter title", "enter game"}},
+		{"pop", func(m *sceneStack[string]) { m.pop(nil) }, []string{"enter title", "exit title"}},
+		{"replace", func(m *sceneStack[string]) { m.replace("game", nil) }, []string{"enter title", "enter game", "exit title"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var log []string
+			m := testScenes(&log)
+			m.reset("title")
+			if len(log) != 0 {
+				t.Fatalf("reset called %v", log)
+			}
+			tt.change(m)
+			// later frames do not enter the first scene again
+			m.flush()
+			if !slices.Equal(log, tt.want) {
+				t.Errorf("log = %v, want %v", log, tt.want)
+			}
+		})
+	}
+}
+
+func TestSceneStackVisible(t *testing.T) {
+	var log []string
+	m := testScenes(&log)
+	m.reset("title")
+	m.push("game", nil)
+	m.push("overlay pause", nil)
+	m.push("overlay confirm", nil)
+	if got, want := m.visible(), []string{"game", "overlay pause", "overlay confirm"}; !slices.Equal(got, want) {
+		t.Errorf("visible = %v, want %v", got, want)
+	}
+	if top, _ := m.top(); top != "overlay confirm" {
+		t.Errorf("top = %q", top)
+	}
+	m.reset("overlay only")
+	if got, want := m.visible(), []string{"overlay only"}; !slices.Equal(got, want) {
+		t.Errorf("visible of a lone overlay = %v, want %v", got, want)
+	}
+	m.pop(nil)
+	if _, ok := m.pop(nil); ok {
+		t.Errorf("pop of an empty stack = true")
+	}
+	if got := m.visible(); len(got) != 0 {
+		t.Errorf("visible of an empty stack = %v", got)
+	}
+}
+
+func TestSceneStackTransition(t *testing.T) {
+	var log []string
+	var drawn []float64
+	m := testScenes(&log)
+	m.reset("title")
+	m.flush()
+	tr := &sceneTransition{
+		duration: 1,
+		ease:     func(t float64) float64 { return t * t },
+		draw:     func(t float64) { drawn = append(drawn, t) },
+	}
+	m.replace("game", tr)
+	if want := []string{"enter title", "enter game"}; !slices.Equal(log, want) {
+		t.Fatalf("log = %v, want the old scene to stay until the transition ends", log)
+	}
+
+	for _, want := range []float64{0.25, 1} {
+		change, p := m.advance(0.5)
+		if change == nil {
+			t.Fatalf("no transition running")
+		}
+		if !slices.Equal(change.from, []string{"title"}) || p != want {
+			t.Errorf("transition from %v at %v, want from [title] at %v", change.from, p, want)
+		}
+		change.draw(p)
+		m.settle()
+	}
+	if want := []string{"enter title", "enter game", "exit title"}; !slices.Equal(log, want) {
+		t.Errorf("log = %v, want %v", log, want)
+	}
+	if change, _ := m.advance(0.5); change != nil {
+		t.Errorf("transition still running after its duration")
+	}
+	if !slices.Equal(drawn, []float64{0.25, 1}) {
+		t.Errorf("drawn at %v", drawn)
+	}
+
+	// a change during a transition finishes the running one first
+	log = nil
+	m.push("overlay pause", tr)
+	m.pop(tr)
+	if want := []string{"enter overlay pause"}; !slices.Equal(log, want) {
+		t.Errorf("log = %v, want %v", log, want)
+	}
+	m.replace("results", nil)
+	if want := []string{"enter overlay pause", "exit overlay pause", "enter results", "exit game"}; !slices.Equal(log, want) {
+		t.Errorf("log = %v, want %v", log, want)
+	}
+
+	// transitions without a duration or a drawing change at once
+	log = nil
+	m.push("a", &sceneTransition{duration: 0, draw: func(float64) {}})
+	m.pop(&sceneTransition{duration: 1})
+	if want := []string{"enter a", "exit a"}; !slices.Equal(log, want) || m.active != nil {
+		t.Errorf("log = %v, want %v at once", log, want)
+	}
+}
@@ -0,0 +1,129 @@
+package p5go
+
+import (
+	"math"
+)
+
+// roundRectSegments is the number of segments used to approximate a rounded corner.
+const roundRectSegments = 8
+
+// RegularPolygonPoints returns the vertices of a regular polygon centered at (x, y), starting at the top.
+func RegularPolygonPoints(x, y, radius float64, sides int) []Vector {
+	if sides < 3 {
+		return nil
+	}
+	pts := make([]Vector, sides)
+	for i := range pts {
+		a := -HALF_PI + TWO_PI*float64(i)/float64(sides)
+		pts[i] = Vector{X: x + radius*math.Cos(a), Y: y + radius*math.Sin(a)}
+	}
+	return pts
+}
+
+// StarPoints returns the vertices of a star centered at (x, y) with the given number of points, starting at the top.
+func StarPoints(x, y, outerRadius, innerRadius float64, points int) []Vector {
+	if points < 2 {
+		return nil
+	}
+	pts := make([]Vector, points*2)
+	for i := range pts {
+		r := outerRadius
+		if i%2 == 1 {
+			r = innerRadius
+		}
+		a := -HALF_PI + PI*float64(i)/float64(points)
+		pts[i] = Vector{X: x + r*math.Cos(a), Y: y + r*math.Sin(a)}
+	}
+	return pts
+}
+
+// RoundRectPoints returns the outline of a rectangle with rounded corners.
+// radii follow p5.js rect: top-left, top-right, bottom-right and bottom-left, where a missing
+// corner takes the radius of the corner before it, so one value rounds every corner.
+func RoundRectPoints(x, y, w, h float64, radii ...float64) []Vector {
+	var r [4]float64
+	for i := range r {
+		switch {
+		case i < len(radii):
+			r[i] = radii[i]
+		case i > 0:
+			r[i] = r[i-1]
+		}
+	}
+	limit := math.Min(math.Abs(w), math.Abs(h)) / 2
+	for i := range r {
+		r[i] = math.Max(0, math.Min(r[i], limit))
+	}
+
+	// corner centers and the angle each corner arc starts at, clockwise from the top-left
+	corners := [4]struct {
+		cx, cy, start float64
+	}{
+		{x + r[0], y + r[0], PI},
+		{x + w - r[1], y + r[1], -HALF_PI},
+		{x + w - r[2], y + h - r[2], 0},
+		{x + r[3], y + h - r[3], HALF_PI},
+	}
+	var pts []Vector
+	for i, cr := range corners {
+		if r[i] == 0 {
+			pts = append(pts, Vector{X: cr.cx, Y: cr.cy})
+			continue
+		}
+		for s := 0; s <= roundRectSegments; s++ {
+			a := cr.start + HALF_PI*float64(s)/roundRectSegments
+			pts = append(pts, Vector{X: cr.cx + r[i]*math.Cos(a), Y: cr.cy + r[i]*math.Sin(a)})
+		}
+	}
+	return pts
+}
+
+// ArrowHeadPoints returns the triangle of an arrow head pointing from (x1, y1) to (x2, y2), with its tip at (x2, y2).
+func ArrowHeadPoints(x1, y1, x2, y2, headSize float64) []Vector {
+	a := math.Atan2(y2-y1, x2-x1)
+	back := Vector{X: x2 - headSize*math.Cos(a), Y: y2 - headSize*math.Sin(a)}
+	half := headSize / 2
+	return []Vector{
+		{X: x2, Y: y2},
+		{X: back.X + half*math.Cos(a+HALF_PI), Y: back.Y + half*math.Sin(a+HALF_PI)},
+		{X: back.X + half*math.Cos(a-HALF_PI), Y: back.Y + half*math.Sin(a-HALF_PI)},
+	}
+}
+
+// DrawPolygon draws a closed shape through the points.
+func DrawPolygon(r Renderer, points []Vector) {
+	if len(points) == 0 {
+		return
+	}
+	r.BeginShape()
+	for _, p := range points {
+		r.Vertex(p.X, p.Y)
+	}
+	r.EndShape(CLOSE)
+}
+
+// DrawPolyline draws an open shape through the points.
+func DrawPolyline(r Renderer, points []Vector) {
+	if len(points) == 0 {
+		return
+	}
+	r.BeginShape()
+	for _, p := range points {
+		r.Vertex(p.X, p.Y)
+	}
+	r.EndShape()
+}
+
+// DrawRoundRect draws a rectangle with rounded corners. See RoundRectPoints for radii.
+func DrawRoundRect(r Renderer, x, y, w, h float64, radii ...float64) {
+	DrawPolygon(r, RoundRectPoints(x, y, w, h, radii...))
+}
+
+// DrawArrow draws a line from (x1, y1) to (x2, y2) with an arrow head at (x2, y2).
+func DrawArrow(r Renderer, x1, y1, x2, y2, headSize float64) {
+	head := ArrowHeadPoints(x1, y1, x2, y2, headSize)
+	// stop the shaft at the base of the head so thick strokes do not poke through the tip
+	base := Vector{X: (head[1].X + head[2].X) / 2, Y: (head[1].Y + head[2].Y) / 2}
+	r.Line(x1, y1, base.X, base.Y)
+	DrawPolygon(r, head)
+}
@@ -0,0 +1,35 @@
+//go:build js && wasm
+
+package p5go
+
+var _ Renderer = (*Canvas)(nil)
+
+// Polygon draws a closed shape through the points.
+func (c *Canvas) Polygon(points []Vector) {
+	DrawPolygon(c, points)
+}
+
+// Polyline draws an open shape through the points.
+func (c *Canvas) Polyline(points []Vector) {
+	DrawPolyline(c, points)
+}
+
+// RegularPolygon draws a regular polygon centered at (x, y) with a vertex at the top.
+func (c *Canvas) RegularPolygon(x, y, radius float64, sides int) {
+	DrawPolygon(c, RegularPolygonPoints(x, y, radius, sides))
+}
+
+// Star draws a star centered at (x, y) with a point at the top.
+func (c *Canvas) Star(x, y, outerRadius, innerRadius float64, points int) {
+	DrawPolygon(c, StarPoints(x, y, outerRadius, innerRadius, points))
+}
+
+// RoundRect draws a rectangle with rounded corners. See RoundRectPoints for radii.
+func (c *Canvas) RoundRect(x, y, w, h float64, radii ...float64) {
+	DrawRoundRect(c, x, y, w, h, radii...)
+}
+
+// Arrow draws a line from (x1, y1) to (x2, y2) with an arrow head at (x2, y2).
+func (c *Canvas) Arrow(x1, y1, x2, y2, headSize float64) {
+	DrawArrow(c, x1, y1, x2, y2, headSize)
+}
@@ -0,0 +1,158 @@
+package p5go
+
+import (
+	"math"
+	"testing"
+)
+
+const shapeEpsilon = 1e-9
+
+func TestRoundRectPointsRadii(t *testing.T) {
+	const x, y, w, h = 10, 20, 100, 50
+	tests := []struct {
+		name  string
+		radii []float64
+		want  [4]float64 // effective radii of the top-left, top-right, bottom-right and bottom-left corners
+	}{
+		{"none", nil, [4]float64{0, 0, 0, 0}},
+		{"one", []float64{5}, [4]float64{5, 5, 5, 5}},
+		{"two", []float64{5, 10}, [4]float64{5, 10, 10, 10}},
+		{"three", []float64{5, 10, 15}, [4]float64{5, 10, 15, 15}},
+		{"four", []float64{5, 10, 15, 20}, [4]float64{5, 10, 15, 20}},
+		{"square corner between round ones", []float64{5, 0, 15, 20}, [4]float64{5, 0, 15, 20}},
+		{"clamped", []float64{100}, [4]float64{25, 25, 25, 25}},
+		{"negative", []float64{-5}, [4]float64{0, 0, 0, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pts := RoundRectPoints(x, y, w, h, tt.radii...)
+			rect := Rectangle{Position: Vector{X: x, Y: y}, Size: Vector{X: w, Y: h}}
+			for _, p := range pts {
+				if p.X < x-shapeEpsilon || p.X > x+w+shapeEpsilon || p.Y < y-shapeEpsilon || p.Y > y+h+shapeEpsilon {
+					t.Errorf("point %v outside the rect", p)
+				}
+			}
+			if b := BoundingBox(pts); b.Position.Dist(rect.Position) > shapeEpsilon || b.Size.Dist(rect.Size) > shapeEpsilon {
+				t.Errorf("bounding box %v, want %v", b, rect)
+			}
+
+			centers := [4]Vector{
+				{X: x + tt.want[0], Y: y + tt.want[0]},
+				{X: x + w - tt.want[1], Y: y + tt.want[1]},
+				{X: x + w - tt.want[2], Y: y + h - tt.want[2]},
+				{X: x + tt.want[3], Y: y + h - tt.want[3]},
+			}
+			// the outline runs clockwise through the corners: an arc of roundRectSegments+1 points
+			// around each round corner and the corner itself for square ones
+			i := 0
+			for c, r := range tt.want {
+				n := 1
+				if r > 0 {
+					n = roundRectSegments + 1
+				}
+				if i+n > len(pts) {
+					t.Fatalf("%d points, too few for corner %d", len(pts), c)
+				}
+				for _, p := range pts[i : i+n] {
+					if d := p.Dist(centers[c]); math.Abs(d-r) > shapeEpsilon {
+						t.Errorf("corner %d: point %v is %v from %v, want %v", c, p, d, centers[c], r)
+					}
+				}
+				i += n
+			}
+			if i != len(pts) {
+				t.Errorf("%d points, want %d", len(pts), i)
+			}
+			if a := PolygonSignedArea(pts); a <= 0 {
+				t.Errorf("signed area %v, want a clockwise outline", a)
+			}
+		})
+	}
+}
+
+func TestRegularPolygonPoints(t *testing.T) {
+	for _, sides := range []int{3, 4, 6, 12} {
+		pts := RegularPolygonPoints(50, 60, 10, sides)
+		if len(pts) != sides {
+			t.Fatalf("%d sides: %d points", sides, len(pts))
+		}
+		if pts[0].Dist(Vector{X: 50, Y: 50}) > shapeEpsilon {
+			t.Errorf("%d sides: first point %v, want the top (50, 50)", sides, pts[0])
+		}
+		side := 2 * 10 * math.Sin(PI/float64(sides))
+		for i, p := range pts {
+			if d := p.Dist(Vector{X: 50, Y: 60}); math.Abs(d-10) > shapeEpsilon {
+				t.Errorf("%d sides: point %d at distance %v from the center, want 10", sides, i, d)
+			}
+			if d := p.Dist(pts[(i+1)%sides]); math.Abs(d-side) > shapeEpsilon {
+				t.Errorf("%d sides: side %d is %v long, want %v", sides, i, d, side)
+			}
+		}
+		if a := PolygonSignedArea(pts); a <= 0 {
+			t.Errorf("%d sides: signed area %v, want a clockwise outline", sides, a)
+		}
+	}
+	if pts := RegularPolygonPoints(0, 0, 10, 2); pts != nil {
+		t.Errorf("2 sides: %v, want nil", pts)
+	}
+}
+
+func TestStarPoints(t *testing.T) {
+	pts := StarPoints(0, 0, 20, 8, 5)
+	if len(pts) != 10 {
+		t.Fatalf("%d points, want 10", len(pts))
+	}
+	if pts[0].Dist(Vector{X: 0, Y: -20}) > shapeEpsilon {
+		t.Errorf("first point %v, want the top (0, -20)", pts[0])
+	}
+	for i, p := range pts {
+		want := 20.0
+		if i%2 == 1 {
+			want = 8
+		}
+		if d := p.Mag(); math.Abs(d-want) > shapeEpsilon {
+			t.Errorf("point %d at radius %v, want %v", i, d, want)
+		}
+		// the points are evenly spaced by angle
+		if a := math.Abs(math.Remainder(pts[(i+1)%10].Heading()-p.Heading(), TWO_PI)); math.Abs(a-PI/5) > shapeEpsilon {
+			t.Errorf("points %d and %d are %v apart, want π/5", i, i+1, a)
+		}
+	}
+	if pts := StarPoints(0, 0, 20, 8, 1); pts != nil {
+		t.Errorf("1 point: %v, want nil", pts)
+	}
+}
+
+func TestArrowHeadPoints(t *testing.T) {
+	tests := []struct {
+		name           string
+		x1, y1, x2, y2 float64
+	}{
+		{"right", 0, 0, 100, 0},
+		{"down", 50, 0, 50, 80},
+		{"diagonal", 10, 10, -30, 40},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			head := ArrowHeadPoints(tt.x1, tt.y1, tt.x2, tt.y2, 12)
+			if len(head) != 3 {
+				t.Fatalf("%d points, want 3", len(head))
+			}
+			tip := Vector{X: tt.x2, Y: tt.y2}
+			dir := tip.Sub(Vector{X: tt.x1, Y: tt.y1}).Normalize()
+			if head[0].Dist(tip) > shapeEpsilon {
+				t.Errorf("tip %v, want %v", head[0], tip)
+			}
+			base := head[1].Add(head[2]).Mult(0.5)
+			if want := tip.Sub(dir.Mult(12)); base.Dist(want) > shapeEpsilon {
+				t.Errorf("base center %v, want %v, one head size behind the tip", base, want)
+			}
+			if d := head[1].Dist(head[2]); math.Abs(d-12) > shapeEpsilon {
+				t.Errorf("base is %v wide, want 12", d)
+			}
+			if d := head[1].Sub(head[2]).Dot(dir); math.Abs(d) > shapeEpsilon {
+				t.Errorf("base is not perpendicular to the arrow")
+			}
+		})
+	}
+}
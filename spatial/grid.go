@@ -0,0 +1,199 @@
+package spatial
+
+import (
+	"math"
+
+	"github.com/ryomak/p5go"
+)
+
+// Grid is a uniform hash grid. It has no bounds and works best when the cell size is close to
+// the typical query radius.
+type Grid[T comparable] struct {
+	cellSize float64
+	cells    map[cell][]Item[T]
+	size     int
+	lo, hi   cell // extent of the cells holding items since the last Clear
+}
+
+type cell struct {
+	x, y int
+}
+
+var _ Index[int] = (*Grid[int])(nil)
+
+// NewGrid creates an empty grid with square cells of the given size. It panics if cellSize is not
+// positive.
+func NewGrid[T comparable](cellSize float64) *Grid[T] {
+	if !(cellSize > 0) || math.IsInf(cellSize, 1) {
+		panic("spatial: grid cell size must be positive and finite")
+	}
+	return &Grid[T]{
+		cellSize: cellSize,
+		cells:    map[cell][]Item[T]{},
+	}
+}
+
+// CellSize returns the size of the grid cells.
+func (g *Grid[T]) CellSize() float64 {
+	return g.cellSize
+}
+
+// Len returns the number of items in the grid.
+func (g *Grid[T]) Len() int {
+	return g.size
+}
+
+// Clear removes every item. The cell storage is kept to avoid allocations when the grid is rebuilt every frame.
+func (g *Grid[T]) Clear() {
+	for k, items := range g.cells {
+		g.cells[k] = items[:0]
+	}
+	g.size = 0
+	g.lo, g.hi = cell{}, cell{}
+}
+
+// Insert adds v at p. It always returns true.
+func (g *Grid[T]) Insert(p p5go.Vector, v T) bool {
+	k := g.cellOf(p)
+	g.cells[k] = append(g.cells[k], Item[T]{Position: p, Value: v})
+	if g.size == 0 {
+		g.lo, g.hi = k, k
+	} else {
+		g.lo = cell{min(g.lo.x, k.x), min(g.lo.y, k.y)}
+		g.hi = cell{max(g.hi.x, k.x), max(g.hi.y, k.y)}
+	}
+	g.size++
+	return true
+}
+
+// Remove removes v at p. It returns false if it was not found.
+func (g *Grid[T]) Remove(p p5go.Vector, v T) bool {
+	k := g.cellOf(p)
+	items := g.cells[k]
+	for i, it := range items {
+		if it.Position == p && it.Value == v {
+			items[i] = items[len(items)-1]
+			g.cells[k] = items[:len(items)-1]
+			g.size--
+			return true
+		}
+	}
+	return false
+}
+
+// Query appends the items inside area to dst.
+func (g *Grid[T]) Query(area p5go.Rectangle, dst []Item[T]) []Item[T] {
+	area = area.Bounds()
+	return g.query(area, func(it Item[T]) bool { return area.Contains(it.Position) }, dst)
+}
+
+// QueryCircle appends the items inside area to dst.
+func (g *Grid[T]) QueryCircle(area p5go.Circle, dst []Item[T]) []Item[T] {
+	return g.query(area.Bounds(), func(it Item[T]) bool { return area.Contains(it.Position) }, dst)
+}
+
+// query appends the items of the cells overlapping area for which keep returns true.
+func (g *Grid[T]) query(area p5go.Rectangle, keep func(Item[T]) bool, dst []Item[T]) []Item[T] {
+	if g.size == 0 {
+		return dst
+	}
+	lo, hi := g.cellOf(area.Min()), g.cellOf(area.Max())
+	lo = cell{max(lo.x, g.lo.x), max(lo.y, g.lo.y)}
+	hi = cell{min(hi.x, g.hi.x), min(hi.y, g.hi.y)}
+	for y := lo.y; y <= hi.y; y++ {
+		for x := lo.x; x <= hi.x; x++ {
+			for _, it := range g.cells[cell{x, y}] {
+				if keep(it) {
+					dst = append(dst, it)
+				}
+			}
+		}
+	}
+	return dst
+}
+
+// Nearest appends the k items nearest to p to dst, nearest first.
+// It searches rings of cells around p until no closer item can remain. Rings are clipped to the
+// cells holding items, and when the rings would visit more cells than the grid has, it scans the
+// items instead, so queries far away from the items or on a sparse grid stay bounded.
+func (g *Grid[T]) Nearest(p p5go.Vector, k int, dst []Item[T]) []Item[T] {
+	if k <= 0 || g.size == 0 {
+		return dst
+	}
+	best := &farthest[T]{}
+	c := g.cellOf(p)
+	// the ring that reaches the farthest cell holding items
+	last := max(c.x-g.lo.x, g.hi.x-c.x, c.y-g.lo.y, g.hi.y-c.y)
+	// rings cover whole cells around p's cell, so the first rings may lie entirely outside the extent
+	first := max(0, g.lo.x-c.x, c.x-g.hi.x, g.lo.y-c.y, c.y-g.hi.y)
+	seen, visited := 0, 0
+	visit := func(x, y int) {
+		visited++
+		for _, it := range g.cells[cell{x, y}] {
+			seen++
+			best.offer(candidate[T]{item: it, d: it.Position.Sub(p).MagSq()}, k)
+		}
+	}
+	for ring := first; ring <= last; ring++ {
+		// every item outside this ring is at least this far from p
+		if b := best.bound(k); b >= 0 {
+			reach := float64(ring-1) * g.cellSize
+			if reach > 0 && reach*reach > b {
+				break
+			}
+		}
+		if visited > len(g.cells) {
+			*best = (*best)[:0]
+			return g.nearestScan(p, k, best, dst)
+		}
+		x0, x1 := max(c.x-ring, g.lo.x), min(c.x+ring, g.hi.x)
+		for y := max(c.y-ring, g.lo.y); y <= min(c.y+ring, g.hi.y); y++ {
+			if ring == 0 || y == c.y-ring || y == c.y+ring {
+				for x := x0; x <= x1; x++ {
+					visit(x, y)
+				}
+				continue
+			}
+			if x := c.x - ring; x >= g.lo.x {
+				visit(x, y)
+			}
+			if x := c.x + ring; x <= g.hi.x {
+				visit(x, y)
+			}
+		}
+		if seen == g.size && best.Len() >= min(k, g.size) {
+			break
+		}
+	}
+	return best.appendSorted(dst)
+}
+
+// nearestScan finds the k nearest items by checking every item.
+func (g *Grid[T]) nearestScan(p p5go.Vector, k int, best *farthest[T], dst []Item[T]) []Item[T] {
+	for _, items := range g.cells {
+		for _, it := range items {
+			best.offer(candidate[T]{item: it, d: it.Position.Sub(p).MagSq()}, k)
+		}
+	}
+	return best.appendSorted(dst)
+}
+
+// Draw draws the occupied cells and the items of the grid, for debugging.
+func (g *Grid[T]) Draw(r p5go.Renderer) {
+	r.Push()
+	r.NoFill()
+	for k, items := range g.cells {
+		if len(items) == 0 {
+			continue
+		}
+		r.Rect(float64(k.x)*g.cellSize, float64(k.y)*g.cellSize, g.cellSize, g.cellSize)
+		for _, it := range items {
+			r.Point(it.Position.X, it.Position.Y)
+		}
+	}
+	r.Pop()
+}
+
+func (g *Grid[T]) cellOf(p p5go.Vector) cell {
+	return cell{int(math.Floor(p.X / g.cellSize)), int(math.Floor(p.Y / g.cellSize))}
+}
@@ -0,0 +1,219 @@
+package spatial
+
+import (
+	"container/heap"
+
+	"github.com/ryomak/p5go"
+)
+
+const (
+	// DefaultCapacity is the number of items a quadtree node holds before it splits.
+	DefaultCapacity = 8
+	// DefaultMaxDepth is the depth below which quadtree nodes no longer split.
+	DefaultMaxDepth = 12
+)
+
+// Quadtree is a point quadtree over a fixed area. Points outside the area are rejected.
+type Quadtree[T comparable] struct {
+	// Capacity is the number of items a node holds before it splits.
+	Capacity int
+	// MaxDepth limits the depth of the tree, so many items at the same position do not split forever.
+	MaxDepth int
+
+	root *node[T]
+	size int
+}
+
+type node[T comparable] struct {
+	bounds   p5go.Rectangle
+	items    []Item[T]
+	children *[4]node[T]
+}
+
+var _ Index[int] = (*Quadtree[int])(nil)
+
+// NewQuadtree creates an empty quadtree covering bounds.
+func NewQuadtree[T comparable](bounds p5go.Rectangle) *Quadtree[T] {
+	return &Quadtree[T]{
+		Capacity: DefaultCapacity,
+		MaxDepth: DefaultMaxDepth,
+		root:     &node[T]{bounds: bounds.Bounds()},
+	}
+}
+
+// Bounds returns the area covered by the tree.
+func (q *Quadtree[T]) Bounds() p5go.Rectangle {
+	return q.root.bounds
+}
+
+// Len returns the number of items in the tree.
+func (q *Quadtree[T]) Len() int {
+	return q.size
+}
+
+// Clear removes every item, keeping the bounds.
+func (q *Quadtree[T]) Clear() {
+	q.root = &node[T]{bounds: q.root.bounds}
+	q.size = 0
+}
+
+// Insert adds v at p. It returns false if p is outside the bounds of the tree.
+func (q *Quadtree[T]) Insert(p p5go.Vector, v T) bool {
+	if !q.root.bounds.Contains(p) {
+		return false
+	}
+	n, depth := q.root, 0
+	for n.children != nil {
+		n = n.child(p)
+		depth++
+	}
+	n.items = append(n.items, Item[T]{Position: p, Value: v})
+	q.size++
+	if len(n.items) > q.Capacity && depth < q.MaxDepth {
+		n.split()
+	}
+	return true
+}
+
+// Remove removes v at p. It returns false if it was not found.
+func (q *Quadtree[T]) Remove(p p5go.Vector, v T) bool {
+	if !q.root.bounds.Contains(p) {
+		return false
+	}
+	n := q.root
+	for n.children != nil {
+		n = n.child(p)
+	}
+	for i, it := range n.items {
+		if it.Position == p && it.Value == v {
+			n.items[i] = n.items[len(n.items)-1]
+			n.items = n.items[:len(n.items)-1]
+			q.size--
+			return true
+		}
+	}
+	return false
+}
+
+// Query appends the items inside area to dst.
+func (q *Quadtree[T]) Query(area p5go.Rectangle, dst []Item[T]) []Item[T] {
+	return q.root.query(area.Bounds(), func(it Item[T]) bool { return true }, dst)
+}
+
+// QueryCircle appends the items inside area to dst.
+func (q *Quadtree[T]) QueryCircle(area p5go.Circle, dst []Item[T]) []Item[T] {
+	return q.root.query(area.Bounds(), func(it Item[T]) bool { return area.Contains(it.Position) }, dst)
+}
+
+// Nearest appends the k items nearest to p to dst, nearest first.
+func (q *Quadtree[T]) Nearest(p p5go.Vector, k int, dst []Item[T]) []Item[T] {
+	if k <= 0 {
+		return dst
+	}
+	best := &farthest[T]{}
+	// best-first search over the nodes ordered by their distance to p
+	nodes := &nodeQueue[T]{{n: q.root, d: distSqToRect(p, q.root.bounds)}}
+	for nodes.Len() > 0 {
+		e := heap.Pop(nodes).(nodeEntry[T])
+		if b := best.bound(k); b >= 0 && e.d > b {
+			break
+		}
+		if e.n.children == nil {
+			for _, it := range e.n.items {
+				best.offer(candidate[T]{item: it, d: it.Position.Sub(p).MagSq()}, k)
+			}
+			continue
+		}
+		for i := range e.n.children {
+			c := &e.n.children[i]
+			heap.Push(nodes, nodeEntry[T]{n: c, d: distSqToRect(p, c.bounds)})
+		}
+	}
+	return best.appendSorted(dst)
+}
+
+// Draw draws the node boundaries and the items of the tree, for debugging.
+func (q *Quadtree[T]) Draw(r p5go.Renderer) {
+	r.Push()
+	r.NoFill()
+	q.root.draw(r)
+	r.Pop()
+}
+
+func (n *node[T]) child(p p5go.Vector) *node[T] {
+	c := n.bounds.Center()
+	i := 0
+	if p.X >= c.X {
+		i |= 1
+	}
+	if p.Y >= c.Y {
+		i |= 2
+	}
+	return &n.children[i]
+}
+
+func (n *node[T]) split() {
+	half := n.bounds.Size.Div(2)
+	pos := n.bounds.Position
+	n.children = &[4]node[T]{
+		{bounds: p5go.Rectangle{Position: pos, Size: half}},
+		{bounds: p5go.Rectangle{Position: p5go.Vector{X: pos.X + half.X, Y: pos.Y}, Size: half}},
+		{bounds: p5go.Rectangle{Position: p5go.Vector{X: pos.X, Y: pos.Y + half.Y}, Size: half}},
+		{bounds: p5go.Rectangle{Position: pos.Add(half), Size: half}},
+	}
+	for _, it := range n.items {
+		c := n.child(it.Position)
+		c.items = append(c.items, it)
+	}
+	n.items = nil
+}
+
+func (n *node[T]) query(area p5go.Rectangle, keep func(Item[T]) bool, dst []Item[T]) []Item[T] {
+	if !n.bounds.Intersects(area) {
+		return dst
+	}
+	if n.children == nil {
+		for _, it := range n.items {
+			if area.Contains(it.Position) && keep(it) {
+				dst = append(dst, it)
+			}
+		}
+		return dst
+	}
+	for i := range n.children {
+		dst = n.children[i].query(area, keep, dst)
+	}
+	return dst
+}
+
+func (n *node[T]) draw(r p5go.Renderer) {
+	r.Rect(n.bounds.Position.X, n.bounds.Position.Y, n.bounds.Size.X, n.bounds.Size.Y)
+	if n.children == nil {
+		for _, it := range n.items {
+			r.Point(it.Position.X, it.Position.Y)
+		}
+		return
+	}
+	for i := range n.children {
+		n.children[i].draw(r)
+	}
+}
+
+type nodeEntry[T comparable] struct {
+	n *node[T]
+	d float64
+}
+
+// nodeQueue is a min-heap of nodes by distance.
+type nodeQueue[T comparable] []nodeEntry[T]
+
+func (h nodeQueue[T]) Len() int           { return len(h) }
+func (h nodeQueue[T]) Less(i, j int) bool { return h[i].d < h[j].d }
+func (h nodeQueue[T]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *nodeQueue[T]) Push(x any)        { *h = append(*h, x.(nodeEntry[T])) }
+func (h *nodeQueue[T]) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
@@ -0,0 +1,85 @@
+// Package spatial provides spatial indexes over p5go vectors for fast neighbour queries.
+package spatial
+
+import (
+	"container/heap"
+
+	"github.com/ryomak/p5go"
+)
+
+// Item is a value stored at a position.
+type Item[T comparable] struct {
+	Position p5go.Vector
+	Value    T
+}
+
+// Index is implemented by Quadtree and Grid.
+// Query methods append their results to dst, which may be nil, so buffers can be reused between frames.
+type Index[T comparable] interface {
+	Insert(p p5go.Vector, v T) bool
+	Remove(p p5go.Vector, v T) bool
+	Len() int
+	Clear()
+	Query(area p5go.Rectangle, dst []Item[T]) []Item[T]
+	QueryCircle(area p5go.Circle, dst []Item[T]) []Item[T]
+	Nearest(p p5go.Vector, k int, dst []Item[T]) []Item[T]
+	Draw(r p5go.Renderer)
+}
+
+// candidate is an item with its squared distance to the query point.
+type candidate[T comparable] struct {
+	item Item[T]
+	d    float64
+}
+
+// farthest keeps the k nearest candidates seen so far, with the farthest on top.
+type farthest[T comparable] []candidate[T]
+
+func (h farthest[T]) Len() int           { return len(h) }
+func (h farthest[T]) Less(i, j int) bool { return h[i].d > h[j].d }
+func (h farthest[T]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *farthest[T]) Push(x any)        { *h = append(*h, x.(candidate[T])) }
+func (h *farthest[T]) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// offer adds a candidate, dropping the farthest one once k are kept.
+func (h *farthest[T]) offer(c candidate[T], k int) {
+	if h.Len() < k {
+		heap.Push(h, c)
+		return
+	}
+	if c.d < (*h)[0].d {
+		(*h)[0] = c
+		heap.Fix(h, 0)
+	}
+}
+
+// bound returns the squared distance a candidate must beat, or -1 while fewer than k are kept.
+func (h farthest[T]) bound(k int) float64 {
+	if len(h) < k {
+		return -1
+	}
+	return h[0].d
+}
+
+// appendSorted appends the candidates to dst from nearest to farthest.
+func (h *farthest[T]) appendSorted(dst []Item[T]) []Item[T] {
+	n := h.Len()
+	start := len(dst)
+	for i := 0; i < n; i++ {
+		dst = append(dst, Item[T]{})
+	}
+	for i := n - 1; i >= 0; i-- {
+		dst[start+i] = heap.Pop(h).(candidate[T]).item
+	}
+	return dst
+}
+
+// distSqToRect returns the squared distance from p to the rectangle, 0 inside it.
+func distSqToRect(p p5go.Vector, r p5go.Rectangle) float64 {
+	return r.ClosestPoint(p).Sub(p).MagSq()
+}
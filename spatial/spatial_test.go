@@ -0,0 +1,212 @@
+package spatial
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/ryomak/p5go"
+)
+
+var world = p5go.Rectangle{Size: p5go.Vector{X: 1000, Y: 1000}}
+
+func randomPoints(rng *rand.Rand, n int) []p5go.Vector {
+	points := make([]p5go.Vector, n)
+	for i := range points {
+		points[i] = p5go.Vector{X: rng.Float64() * world.Size.X, Y: rng.Float64() * world.Size.Y}
+	}
+	return points
+}
+
+func indexes() map[string]func() Index[int] {
+	return map[string]func() Index[int]{
+		"quadtree": func() Index[int] { return NewQuadtree[int](world) },
+		"grid":     func() Index[int] { return NewGrid[int](25) },
+	}
+}
+
+func fill(idx Index[int], points []p5go.Vector) {
+	for i, p := range points {
+		idx.Insert(p, i)
+	}
+}
+
+func values(items []Item[int]) []int {
+	res := make([]int, len(items))
+	for i, it := range items {
+		res[i] = it.Value
+	}
+	sort.Ints(res)
+	return res
+}
+
+func equalValues(t *testing.T, what string, got, want []int) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s: %d items, want %d", what, len(got), len(want))
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Fatalf("%s: items %v, want %v", what, got, want)
+		}
+	}
+}
+
+func TestIndexMatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	points := randomPoints(rng, 2000)
+	for name, create := range indexes() {
+		t.Run(name, func(t *testing.T) {
+			idx := create()
+			fill(idx, points)
+			// remove a tenth of the points and check they are gone from every query
+			removed := map[int]bool{}
+			for i := 0; i < len(points); i += 10 {
+				if !idx.Remove(points[i], i) {
+					t.Fatalf("Remove(%d) = false", i)
+				}
+				removed[i] = true
+			}
+			if idx.Remove(points[0], 0) {
+				t.Fatalf("Remove of a removed item = true")
+			}
+			if idx.Len() != len(points)-len(removed) {
+				t.Fatalf("Len = %d, want %d", idx.Len(), len(points)-len(removed))
+			}
+
+			for q := 0; q < 50; q++ {
+				center := randomPoints(rng, 1)[0]
+				rect := p5go.Rectangle{Position: center, Size: p5go.Vector{X: rng.Float64() * 200, Y: rng.Float64() * 200}}
+				circle := p5go.Circle{Position: center, Diameter: rng.Float64() * 200}
+				var inRect, inCircle []int
+				for i, p := range points {
+					if removed[i] {
+						continue
+					}
+					if rect.Contains(p) {
+						inRect = append(inRect, i)
+					}
+					if circle.Contains(p) {
+						inCircle = append(inCircle, i)
+					}
+				}
+				equalValues(t, "Query", values(idx.Query(rect, nil)), inRect)
+				equalValues(t, "QueryCircle", values(idx.QueryCircle(circle, nil)), inCircle)
+
+				k := 1 + rng.Intn(20)
+				var dists []float64
+				for i, p := range points {
+					if !removed[i] {
+						dists = append(dists, p.Sub(center).MagSq())
+					}
+				}
+				sort.Float64s(dists)
+				got := idx.Nearest(center, k, nil)
+				if len(got) != k {
+					t.Fatalf("Nearest(%d) returned %d items", k, len(got))
+				}
+				for i, it := range got {
+					if d := it.Position.Sub(center).MagSq(); d != dists[i] {
+						t.Fatalf("Nearest item %d at squared distance %v, want %v", i, d, dists[i])
+					}
+				}
+			}
+
+			if got := idx.Nearest(p5go.Vector{}, len(points), nil); len(got) != idx.Len() {
+				t.Errorf("Nearest(all) returned %d items, want %d", len(got), idx.Len())
+			}
+			idx.Clear()
+			if idx.Len() != 0 || len(idx.Query(world, nil)) != 0 || len(idx.Nearest(p5go.Vector{}, 3, nil)) != 0 {
+				t.Errorf("items left after Clear")
+			}
+		})
+	}
+}
+
+func TestGridNearestFarAway(t *testing.T) {
+	g := NewGrid[int](1)
+	g.Insert(p5go.Vector{X: 0, Y: 0}, 1)
+	g.Insert(p5go.Vector{X: 1e5, Y: 1e5}, 2)
+
+	// a query far outside the items, and one between two items on a very sparse grid
+	for _, p := range []p5go.Vector{{X: -1e7, Y: 3e6}, {X: 4e4, Y: 4e4}} {
+		got := g.Nearest(p, 1, nil)
+		if len(got) != 1 || got[0].Value != 1 {
+			t.Errorf("Nearest(%v) = %v, want item 1", p, got)
+		}
+	}
+	if got := g.Nearest(p5go.Vector{X: 2e5, Y: 2e5}, 2, nil); len(got) != 2 || got[0].Value != 2 || got[1].Value != 1 {
+		t.Errorf("Nearest(2) = %v, want items 2 and 1", got)
+	}
+}
+
+func TestNewGridRejectsInvalidCellSize(t *testing.T) {
+	for _, size := range []float64{0, -1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("NewGrid(%v) did not panic", size)
+				}
+			}()
+			NewGrid[int](size)
+		}()
+	}
+}
+
+var benchSizes = []int{10_000, 100_000}
+
+func benchIndexes(b *testing.B, f func(b *testing.B, idx Index[int], points []p5go.Vector)) {
+	for _, n := range benchSizes {
+		points := randomPoints(rand.New(rand.NewSource(1)), n)
+		for name, create := range indexes() {
+			b.Run(fmt.Sprintf("%s/%d", name, n), func(b *testing.B) {
+				f(b, create(), points)
+			})
+		}
+	}
+}
+
+func BenchmarkInsert(b *testing.B) {
+	benchIndexes(b, func(b *testing.B, idx Index[int], points []p5go.Vector) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			idx.Clear()
+			fill(idx, points)
+		}
+	})
+}
+
+func BenchmarkQuery(b *testing.B) {
+	benchIndexes(b, func(b *testing.B, idx Index[int], points []p5go.Vector) {
+		fill(idx, points)
+		var dst []Item[int]
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			p := points[i%len(points)]
+			dst = idx.Query(p5go.Rectangle{Position: p, Size: p5go.Vector{X: 50, Y: 50}}, dst[:0])
+		}
+	})
+}
+
+func BenchmarkQueryCircle(b *testing.B) {
+	benchIndexes(b, func(b *testing.B, idx Index[int], points []p5go.Vector) {
+		fill(idx, points)
+		var dst []Item[int]
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			dst = idx.QueryCircle(p5go.Circle{Position: points[i%len(points)], Diameter: 50}, dst[:0])
+		}
+	})
+}
+
+func BenchmarkNearest(b *testing.B) {
+	benchIndexes(b, func(b *testing.B, idx Index[int], points []p5go.Vector) {
+		fill(idx, points)
+		var dst []Item[int]
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			dst = idx.Nearest(points[i%len(points)], 8, dst[:0])
+		}
+	})
+}
@@ -0,0 +1,163 @@
+package sprite
+
+import (
+	"github.com/ryomak/p5go"
+)
+
+// DefaultFrameDuration is the duration in seconds of frames without one of their own in clips
+// without a frame duration.
+const DefaultFrameDuration = 0.1
+
+// AnimatedSprite plays the clips of a sheet.
+type AnimatedSprite struct {
+	Sheet    *Sheet
+	Position p5go.Vector
+	// Origin is the point of the untrimmed frame placed at Position, as a fraction of its size:
+	// {0, 0} is the top-left corner and {0.5, 1} the bottom center.
+	Origin       p5go.Vector
+	Scale        float64
+	Rotation     float64
+	FlipX, FlipY bool    // mirror the frame within its untrimmed rectangle
+	Speed        float64 // playback rate, 1 for normal speed
+	// OnEnd is called when a clip that does not loop reaches its last frame.
+	OnEnd func(clip string)
+
+	clip    *Clip
+	step    int // position in the play order of the clip
+	elapsed float64
+	done    bool
+}
+
+// NewAnimatedSprite creates a sprite of sheet showing its first frame.
+func NewAnimatedSprite(sheet *Sheet) *AnimatedSprite {
+	return &AnimatedSprite{Sheet: sheet, Scale: 1, Speed: 1}
+}
+
+// Play starts the clip named name. Playing the current clip again does nothing; use Restart to
+// start it over. It returns false if the sheet has no such clip.
+func (a *AnimatedSprite) Play(name string) bool {
+	if a.clip != nil && a.clip.Name == name {
+		return true
+	}
+	clip, ok := a.Sheet.Clips[name]
+	if !ok {
+		return false
+	}
+	a.clip = clip
+	a.Restart()
+	return true
+}
+
+// Restart starts the current clip from its first frame.
+func (a *AnimatedSprite) Restart() {
+	a.step, a.elapsed, a.done = 0, 0, false
+}
+
+// Clip returns the name of the current clip.
+func (a *AnimatedSprite) Clip() string {
+	if a.clip == nil {
+		return ""
+	}
+	return a.clip.Name
+}
+
+// Done returns true when a clip that does not loop has finished.
+func (a *AnimatedSprite) Done() bool {
+	return a.done
+}
+
+// Index returns the index of the current frame in the sheet.
+func (a *AnimatedSprite) Index() int {
+	if a.clip == nil || len(a.clip.Frames) == 0 {
+		return 0
+	}
+	n := len(a.clip.Frames)
+	if a.step < n {
+		return a.clip.Frames[a.step]
+	}
+	return a.clip.Frames[2*n-2-a.step]
+}
+
+// Frame returns the current frame, or the zero Frame if the sheet has no such frame.
+func (a *AnimatedSprite) Frame() Frame {
+	i := a.Index()
+	if i < 0 || i >= len(a.Sheet.Frames) {
+		return Frame{}
+	}
+	return a.Sheet.Frames[i]
+}
+
+// Update advances the animation by dt seconds.
+func (a *AnimatedSprite) Update(dt float64) {
+	if a.clip == nil || a.done || len(a.clip.Frames) == 0 {
+		return
+	}
+	steps := len(a.clip.Frames)
+	if a.clip.PingPong && steps > 1 {
+		steps = 2*steps - 2
+	}
+	a.elapsed += dt * a.Speed
+	for {
+		d := a.duration()
+		if a.elapsed < d {
+			return
+		}
+		if a.step == steps-1 && !a.clip.Loop {
+			a.elapsed, a.done = 0, true
+			if a.OnEnd != nil {
+				a.OnEnd(a.clip.Name)
+			}
+			return
+		}
+		a.elapsed -= d
+		a.step = (a.step + 1) % steps
+	}
+}
+
+// duration returns the duration of the current frame.
+func (a *AnimatedSprite) duration() float64 {
+	if d := a.Frame().Duration; d > 0 {
+		return d
+	}
+	if a.clip.FrameDuration > 0 {
+		return a.clip.FrameDuration
+	}
+	return DefaultFrameDuration
+}
+
+// Bounds returns the untrimmed frame rectangle of the sprite, ignoring Rotation.
+func (a *AnimatedSprite) Bounds() p5go.Rectangle {
+	size := a.Frame().Size.Mult(a.Scale)
+	return p5go.Rectangle{
+		Position: p5go.Vector{X: a.Position.X - a.Origin.X*size.X, Y: a.Position.Y - a.Origin.Y*size.Y},
+		Size:     size,
+	}
+}
+
+// Draw draws the current frame.
+func (a *AnimatedSprite) Draw(d Drawer) {
+	if len(a.Sheet.Frames) == 0 {
+		return
+	}
+	f := a.Frame()
+	d.Push()
+	d.Translate(a.Position.X, a.Position.Y)
+	if a.Rotation != 0 {
+		d.Rotate(a.Rotation)
+	}
+	d.ApplyMatrix(a.Scale, 0, 0, a.Scale, 0, 0)
+	d.Translate(-a.Origin.X*f.Size.X, -a.Origin.Y*f.Size.Y)
+	if a.FlipX || a.FlipY {
+		// mirror inside the untrimmed frame, so the sprite stays within Bounds
+		sx, sy, dx, dy := 1.0, 1.0, 0.0, 0.0
+		if a.FlipX {
+			sx, dx = -1, f.Size.X
+		}
+		if a.FlipY {
+			sy, dy = -1, f.Size.Y
+		}
+		d.ApplyMatrix(sx, 0, 0, sy, dx, dy)
+	}
+	f.draw(d, a.Sheet.Image)
+	d.Pop()
+}
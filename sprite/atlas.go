@@ -0,0 +1,132 @@
+package sprite
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/ryomak/p5go"
+)
+
+type atlasRect struct {
+	X, Y, W, H float64
+}
+
+type atlasSize struct {
+	W, H float64
+}
+
+type atlasFrame struct {
+	Filename         string
+	Frame            atlasRect
+	Rotated          bool
+	Trimmed          bool
+	SpriteSourceSize atlasRect
+	SourceSize       atlasSize
+	Duration         float64 // milliseconds, written by Aseprite
+}
+
+type atlasFile struct {
+	Frames     json.RawMessage
+	Animations map[string][]string // written by TexturePacker for Pixi and Phaser
+	Meta       struct {
+		FrameTags []struct {
+			Name      string
+			From, To  int
+			Direction string
+			Repeat    string
+		}
+	}
+}
+
+// ParseAtlas parses a JSON atlas of image in the hash or array format written by TexturePacker and
+// Aseprite. Aseprite frame tags and TexturePacker animations become clips.
+func ParseAtlas(image any, data []byte) (*Sheet, error) {
+	var file atlasFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("sprite: parse atlas: %w", err)
+	}
+	entries, err := atlasFrames(file.Frames)
+	if err != nil {
+		return nil, fmt.Errorf("sprite: parse atlas frames: %w", err)
+	}
+
+	frames := make([]Frame, len(entries))
+	for i, e := range entries {
+		f := Frame{
+			Name:     e.Filename,
+			Rect:     p5go.Rectangle{Position: p5go.Vector{X: e.Frame.X, Y: e.Frame.Y}, Size: p5go.Vector{X: e.Frame.W, Y: e.Frame.H}},
+			Rotated:  e.Rotated,
+			Size:     p5go.Vector{X: e.SourceSize.W, Y: e.SourceSize.H},
+			Duration: e.Duration / 1000,
+		}
+		if e.Trimmed {
+			f.Offset = p5go.Vector{X: e.SpriteSourceSize.X, Y: e.SpriteSourceSize.Y}
+		}
+		if f.Size == (p5go.Vector{}) {
+			f.Size = f.Rect.Size
+		}
+		frames[i] = f
+	}
+	s := NewSheet(image, frames)
+
+	for name, names := range file.Animations {
+		s.AddClipNames(name, 0, true, names...)
+	}
+	for _, tag := range file.Meta.FrameTags {
+		if tag.From < 0 || tag.To >= len(frames) || tag.From > tag.To {
+			return nil, fmt.Errorf("sprite: frame tag %q is out of range", tag.Name)
+		}
+		indices := make([]int, 0, tag.To-tag.From+1)
+		for i := tag.From; i <= tag.To; i++ {
+			indices = append(indices, i)
+		}
+		if tag.Direction == "reverse" || tag.Direction == "pingpong_reverse" {
+			for i, j := 0, len(indices)-1; i < j; i, j = i+1, j-1 {
+				indices[i], indices[j] = indices[j], indices[i]
+			}
+		}
+		clip := s.AddClip(tag.Name, 0, true, indices...)
+		clip.PingPong = tag.Direction == "pingpong" || tag.Direction == "pingpong_reverse"
+		if n, err := strconv.Atoi(tag.Repeat); err == nil && n > 0 {
+			clip.Loop = false
+		}
+	}
+	return s, nil
+}
+
+// atlasFrames decodes the frames of the array format, or of the hash format in file order.
+func atlasFrames(raw json.RawMessage) ([]atlasFrame, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	if raw[0] == '[' {
+		var frames []atlasFrame
+		err := json.Unmarshal(raw, &frames)
+		return frames, err
+	}
+
+	if raw[0] != '{' {
+		return nil, fmt.Errorf("frames are neither an array nor an object")
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	var frames []atlasFrame
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		var f atlasFrame
+		if err := dec.Decode(&f); err != nil {
+			return nil, err
+		}
+		f.Filename = key.(string)
+		frames = append(frames, f)
+	}
+	return frames, nil
+}
@@ -0,0 +1,20 @@
+//go:build js && wasm
+
+package sprite
+
+import (
+	"github.com/ryomak/p5go"
+)
+
+// Load loads an image and its JSON atlas and passes the parsed sheet to loaded. Called from the
+// preload handler, setup waits until both are loaded.
+func Load(c *p5go.Canvas, imagePath, atlasPath string, loaded func(s *Sheet, err error)) {
+	image := c.LoadImage(imagePath)
+	c.LoadJSON(atlasPath, func(data []byte, err error) {
+		if err != nil {
+			loaded(nil, err)
+			return
+		}
+		loaded(ParseAtlas(image, data))
+	})
+}
@@ -0,0 +1,149 @@
+// Package sprite slices sprite sheets and texture atlases into frames and plays frame animations.
+package sprite
+
+import (
+	"math"
+
+	"github.com/ryomak/p5go"
+)
+
+// Drawer is the set of drawing calls used to draw frames. p5go.Canvas implements it.
+type Drawer interface {
+	Push()
+	Pop()
+	Translate(x, y float64, z ...float64)
+	Rotate(angle float64)
+	ApplyMatrix(a, b, c, d, e, f float64)
+	Image(img any, opts ...any)
+}
+
+// Frame is a sub-rectangle of a sheet image.
+type Frame struct {
+	Name string
+	// Rect is the region of the image. For rotated frames its size is the unrotated size, and the
+	// image holds the region turned 90 degrees clockwise.
+	Rect    p5go.Rectangle
+	Rotated bool
+	// Offset is the position of Rect in the untrimmed frame of size Size. Atlases trim the
+	// transparent border of frames; Offset and Size restore it.
+	Offset   p5go.Vector
+	Size     p5go.Vector
+	Duration float64 // seconds, 0 to use the frame duration of the clip
+}
+
+// Clip is a named sequence of frames.
+type Clip struct {
+	Name          string
+	Frames        []int   // indices into Sheet.Frames
+	FrameDuration float64 // seconds of the frames without a duration of their own
+	Loop          bool
+	PingPong      bool // play forward, then backward
+}
+
+// Sheet is an image sliced into frames.
+type Sheet struct {
+	Image  any // an image loaded with Canvas.LoadImage, or anything Drawer.Image accepts
+	Frames []Frame
+	Clips  map[string]*Clip
+
+	names map[string]int
+}
+
+// NewSheet creates a sheet of the frames of image.
+func NewSheet(image any, frames []Frame) *Sheet {
+	s := &Sheet{Image: image, Frames: frames, Clips: map[string]*Clip{}, names: map[string]int{}}
+	for i, f := range frames {
+		if f.Name != "" {
+			s.names[f.Name] = i
+		}
+	}
+	return s
+}
+
+// Grid describes a sheet of equally sized frames laid out in rows.
+type Grid struct {
+	Width, Height           float64 // size of the image
+	FrameWidth, FrameHeight float64
+	Margin                  float64 // space around the frames
+	Spacing                 float64 // space between the frames
+	Count                   int     // number of frames, 0 for every cell
+}
+
+// FromGrid slices image into the cells of g, row by row. The sheet has no frames if the frame
+// size is not positive or the spacing is so negative that the cells do not advance.
+func FromGrid(image any, g Grid) *Sheet {
+	var frames []Frame
+	if g.FrameWidth <= 0 || g.FrameHeight <= 0 || g.FrameWidth+g.Spacing <= 0 || g.FrameHeight+g.Spacing <= 0 {
+		return NewSheet(image, frames)
+	}
+	size := p5go.Vector{X: g.FrameWidth, Y: g.FrameHeight}
+	for y := g.Margin; y+g.FrameHeight <= g.Height-g.Margin; y += g.FrameHeight + g.Spacing {
+		for x := g.Margin; x+g.FrameWidth <= g.Width-g.Margin; x += g.FrameWidth + g.Spacing {
+			if g.Count > 0 && len(frames) == g.Count {
+				return NewSheet(image, frames)
+			}
+			frames = append(frames, Frame{Rect: p5go.Rectangle{Position: p5go.Vector{X: x, Y: y}, Size: size}, Size: size})
+		}
+	}
+	return NewSheet(image, frames)
+}
+
+// Index returns the index of the frame named name, or -1.
+func (s *Sheet) Index(name string) int {
+	if i, ok := s.names[name]; ok {
+		return i
+	}
+	return -1
+}
+
+// Frame returns the frame named name.
+func (s *Sheet) Frame(name string) (Frame, bool) {
+	i := s.Index(name)
+	if i < 0 {
+		return Frame{}, false
+	}
+	return s.Frames[i], true
+}
+
+// AddClip adds a clip of the frames at indices, played at fps frames per second.
+func (s *Sheet) AddClip(name string, fps float64, loop bool, frames ...int) *Clip {
+	clip := &Clip{Name: name, Frames: frames, Loop: loop}
+	if fps > 0 {
+		clip.FrameDuration = 1 / fps
+	}
+	s.Clips[name] = clip
+	return clip
+}
+
+// AddClipNames adds a clip of the named frames. Unknown names are skipped.
+func (s *Sheet) AddClipNames(name string, fps float64, loop bool, frames ...string) *Clip {
+	indices := make([]int, 0, len(frames))
+	for _, f := range frames {
+		if i := s.Index(f); i >= 0 {
+			indices = append(indices, i)
+		}
+	}
+	return s.AddClip(name, fps, loop, indices...)
+}
+
+// Draw draws the frame at index i with its untrimmed top-left corner at x, y.
+func (s *Sheet) Draw(d Drawer, i int, x, y float64) {
+	d.Push()
+	d.Translate(x, y)
+	s.Frames[i].draw(d, s.Image)
+	d.Pop()
+}
+
+// draw draws the frame with its untrimmed top-left corner at the origin.
+func (f Frame) draw(d Drawer, image any) {
+	pos, w, h := f.Rect.Position, f.Rect.Size.X, f.Rect.Size.Y
+	if !f.Rotated {
+		d.Image(image, f.Offset.X, f.Offset.Y, w, h, pos.X, pos.Y, w, h)
+		return
+	}
+	d.Push()
+	d.Translate(f.Offset.X, f.Offset.Y+h)
+	d.Rotate(-math.Pi / 2)
+	d.Image(image, 0, 0, h, w, pos.X, pos.Y, h, w)
+	d.Pop()
+}
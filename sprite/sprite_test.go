@@ -0,0 +1,378 @@
+package sprite
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/ryomak/p5go"
+)
+
+// matrix is a 2D affine transform mapping (x, y) to (a*x + c*y + e, b*x + d*y + f), as in p5.js.
+type matrix struct{ a, b, c, d, e, f float64 }
+
+func (m matrix) mul(n matrix) matrix {
+	return matrix{
+		a: m.a*n.a + m.c*n.b, b: m.b*n.a + m.d*n.b,
+		c: m.a*n.c + m.c*n.d, d: m.b*n.c + m.d*n.d,
+		e: m.a*n.e + m.c*n.f + m.e, f: m.b*n.e + m.d*n.f + m.f,
+	}
+}
+
+func (m matrix) apply(x, y float64) p5go.Vector {
+	return p5go.Vector{X: m.a*x + m.c*y + m.e, Y: m.b*x + m.d*y + m.f}
+}
+
+// drawn is an image call: where the corners of the destination land on the canvas, starting with
+// the one matching the top-left corner of the source, and the source rectangle.
+type drawn struct {
+	corners [4]p5go.Vector
+	source  p5go.Rectangle
+}
+
+// fakeDrawer tracks the transform like p5.js and records the images drawn.
+type fakeDrawer struct {
+	m      matrix
+	stack  []matrix
+	images []drawn
+}
+
+func newFakeDrawer() *fakeDrawer {
+	return &fakeDrawer{m: matrix{a: 1, d: 1}}
+}
+
+func (d *fakeDrawer) Push() { d.stack = append(d.stack, d.m) }
+func (d *fakeDrawer) Pop() {
+	d.m = d.stack[len(d.stack)-1]
+	d.stack = d.stack[:len(d.stack)-1]
+}
+func (d *fakeDrawer) Translate(x, y float64, z ...float64) {
+	d.m = d.m.mul(matrix{a: 1, d: 1, e: x, f: y})
+}
+func (d *fakeDrawer) Rotate(angle float64) {
+	s, c := math.Sincos(angle)
+	d.m = d.m.mul(matrix{a: c, b: s, c: -s, d: c})
+}
+func (d *fakeDrawer) ApplyMatrix(a, b, c, dd, e, f float64) {
+	d.m = d.m.mul(matrix{a, b, c, dd, e, f})
+}
+func (d *fakeDrawer) Image(img any, opts ...any) {
+	v := make([]float64, len(opts))
+	for i, o := range opts {
+		switch o := o.(type) {
+		case int:
+			v[i] = float64(o)
+		case float64:
+			v[i] = o
+		}
+	}
+	x, y, w, h := v[0], v[1], v[2], v[3]
+	d.images = append(d.images, drawn{
+		corners: [4]p5go.Vector{d.m.apply(x, y), d.m.apply(x+w, y), d.m.apply(x+w, y+h), d.m.apply(x, y+h)},
+		source:  p5go.Rectangle{Position: p5go.Vector{X: v[4], Y: v[5]}, Size: p5go.Vector{X: v[6], Y: v[7]}},
+	})
+}
+
+func near(a, b p5go.Vector) bool {
+	return math.Abs(a.X-b.X) < 1e-9 && math.Abs(a.Y-b.Y) < 1e-9
+}
+
+// bounds returns the axis-aligned box of the corners.
+func (dr drawn) bounds() p5go.Rectangle {
+	return p5go.BoundingBox(dr.corners[:])
+}
+
+const atlasHash = `{
+	"frames": {
+		"walk_10": {"frame": {"x": 0, "y": 0, "w": 16, "h": 24}, "rotated": false, "trimmed": false,
+			"spriteSourceSize": {"x": 0, "y": 0, "w": 16, "h": 24}, "sourceSize": {"w": 16, "h": 24}, "duration": 50},
+		"2": {"frame": {"x": 16, "y": 0, "w": 10, "h": 20}, "rotated": false, "trimmed": true,
+			"spriteSourceSize": {"x": 3, "y": 4, "w": 10, "h": 20}, "sourceSize": {"w": 16, "h": 24}, "duration": 100},
+		"1": {"frame": {"x": 26, "y": 0, "w": 12, "h": 20}, "rotated": true, "trimmed": true,
+			"spriteSourceSize": {"x": 2, "y": 1, "w": 12, "h": 20}, "sourceSize": {"w": 16, "h": 24}, "duration": 150}
+	},
+	"animations": {"all": ["walk_10", "1", "missing"]},
+	"meta": {"frameTags": [
+		{"name": "fwd", "from": 0, "to": 2, "direction": "forward"},
+		{"name": "back", "from": 0, "to": 2, "direction": "reverse", "repeat": "2"},
+		{"name": "bounce", "from": 1, "to": 2, "direction": "pingpong"}
+	]}
+}`
+
+func TestParseAtlasHash(t *testing.T) {
+	s, err := ParseAtlas("img", []byte(atlasHash))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// frames keep the order of the file, even for keys that look like integers
+	var names []string
+	for _, f := range s.Frames {
+		names = append(names, f.Name)
+	}
+	if want := []string{"walk_10", "2", "1"}; !slices.Equal(names, want) {
+		t.Fatalf("frames %v, want %v", names, want)
+	}
+	f, ok := s.Frame("2")
+	if !ok || f.Offset != (p5go.Vector{X: 3, Y: 4}) || f.Size != (p5go.Vector{X: 16, Y: 24}) || f.Duration != 0.1 {
+		t.Errorf("trimmed frame = %+v", f)
+	}
+	if f := s.Frames[2]; !f.Rotated || f.Rect.Size != (p5go.Vector{X: 12, Y: 20}) {
+		t.Errorf("rotated frame = %+v", f)
+	}
+
+	clips := []struct {
+		name     string
+		frames   []int
+		loop     bool
+		pingPong bool
+	}{
+		{"all", []int{0, 2}, true, false},
+		{"fwd", []int{0, 1, 2}, true, false},
+		{"back", []int{2, 1, 0}, false, false},
+		{"bounce", []int{1, 2}, true, true},
+	}
+	for _, c := range clips {
+		clip := s.Clips[c.name]
+		if clip == nil {
+			t.Errorf("no clip %q", c.name)
+			continue
+		}
+		if !slices.Equal(clip.Frames, c.frames) || clip.Loop != c.loop || clip.PingPong != c.pingPong {
+			t.Errorf("clip %q = %+v, want frames %v, loop %v, ping-pong %v", c.name, clip, c.frames, c.loop, c.pingPong)
+		}
+	}
+}
+
+func TestParseAtlasArray(t *testing.T) {
+	data := `{"frames": [
+		{"filename": "a", "frame": {"x": 0, "y": 0, "w": 8, "h": 8}},
+		{"filename": "b", "frame": {"x": 8, "y": 0, "w": 8, "h": 8}}
+	]}`
+	s, err := ParseAtlas(nil, []byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Frames) != 2 || s.Index("b") != 1 || s.Frames[1].Size != (p5go.Vector{X: 8, Y: 8}) {
+		t.Errorf("frames = %+v", s.Frames)
+	}
+}
+
+func TestParseAtlasErrors(t *testing.T) {
+	for name, data := range map[string]string{
+		"syntax":        `{"frames": `,
+		"frames":        `{"frames": 3}`,
+		"tag out range": `{"frames": [{"filename": "a"}], "meta": {"frameTags": [{"name": "x", "from": 0, "to": 1}]}}`,
+	} {
+		if _, err := ParseAtlas(nil, []byte(data)); err == nil {
+			t.Errorf("%s: no error", name)
+		}
+	}
+}
+
+func TestFramePlacement(t *testing.T) {
+	s, err := ParseAtlas("img", []byte(atlasHash))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		frame  int
+		bounds p5go.Rectangle
+		// where the top-left corner of the source region lands
+		sourceOrigin p5go.Vector
+	}{
+		{"plain", 0, p5go.Rectangle{Position: p5go.Vector{X: 100, Y: 50}, Size: p5go.Vector{X: 16, Y: 24}}, p5go.Vector{X: 100, Y: 50}},
+		{"trimmed", 1, p5go.Rectangle{Position: p5go.Vector{X: 103, Y: 54}, Size: p5go.Vector{X: 10, Y: 20}}, p5go.Vector{X: 103, Y: 54}},
+		// the atlas holds the frame turned clockwise, so its top-left corner is the bottom-left of the frame
+		{"rotated", 2, p5go.Rectangle{Position: p5go.Vector{X: 102, Y: 51}, Size: p5go.Vector{X: 12, Y: 20}}, p5go.Vector{X: 102, Y: 71}},
+	}
+	for _, tt := range tests {
+		d := newFakeDrawer()
+		s.Draw(d, tt.frame, 100, 50)
+		if len(d.images) != 1 || len(d.stack) != 0 {
+			t.Fatalf("%s: %d images with %d pushes left", tt.name, len(d.images), len(d.stack))
+		}
+		img := d.images[0]
+		if b := img.bounds(); !near(b.Position, tt.bounds.Position) || !near(b.Size, tt.bounds.Size) {
+			t.Errorf("%s: drawn at %v, want %v", tt.name, b, tt.bounds)
+		}
+		if !near(img.corners[0], tt.sourceOrigin) {
+			t.Errorf("%s: source origin at %v, want %v", tt.name, img.corners[0], tt.sourceOrigin)
+		}
+		f := s.Frames[tt.frame]
+		want := f.Rect
+		if f.Rotated {
+			want.Size = p5go.Vector{X: f.Rect.Size.Y, Y: f.Rect.Size.X}
+		}
+		if img.source != want {
+			t.Errorf("%s: source %v, want %v", tt.name, img.source, want)
+		}
+	}
+
+	// a sprite with its origin at the bottom center, flipped and scaled, draws within its bounds
+	a := NewAnimatedSprite(s)
+	a.Play("fwd")
+	a.Update(0.06)
+	a.Position, a.Origin, a.Scale, a.FlipX = p5go.Vector{X: 200, Y: 100}, p5go.Vector{X: 0.5, Y: 1}, 2, true
+	want := p5go.Rectangle{Position: p5go.Vector{X: 184, Y: 52}, Size: p5go.Vector{X: 32, Y: 48}}
+	if b := a.Bounds(); b != want {
+		t.Errorf("Bounds = %v, want %v", b, want)
+	}
+	d := newFakeDrawer()
+	a.Draw(d)
+	// the trimmed frame starts 3 pixels from the left of the untrimmed one, which is the right once flipped
+	img := d.images[0].bounds()
+	if drawn := (p5go.Rectangle{Position: p5go.Vector{X: 190, Y: 60}, Size: p5go.Vector{X: 20, Y: 40}}); !near(img.Position, drawn.Position) || !near(img.Size, drawn.Size) {
+		t.Errorf("sprite drawn at %v, want %v", img, drawn)
+	}
+}
+
+func TestFlipStaysInBounds(t *testing.T) {
+	s, err := ParseAtlas("img", []byte(atlasHash))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name         string
+		frame        int
+		flipX, flipY bool
+		// the drawn rectangle and where the top-left corner of the source lands
+		drawn        p5go.Rectangle
+		sourceOrigin p5go.Vector
+	}{
+		{"plain flip x", 0, true, false, p5go.Rectangle{Position: p5go.Vector{X: 100, Y: 50}, Size: p5go.Vector{X: 16, Y: 24}}, p5go.Vector{X: 116, Y: 50}},
+		{"plain flip y", 0, false, true, p5go.Rectangle{Position: p5go.Vector{X: 100, Y: 50}, Size: p5go.Vector{X: 16, Y: 24}}, p5go.Vector{X: 100, Y: 74}},
+		// the trimmed frame sits 3 pixels from the left of its 16 pixel frame, so 3 from the right once flipped
+		{"trimmed flip x", 1, true, false, p5go.Rectangle{Position: p5go.Vector{X: 103, Y: 54}, Size: p5go.Vector{X: 10, Y: 20}}, p5go.Vector{X: 113, Y: 54}},
+		{"rotated flip both", 2, true, true, p5go.Rectangle{Position: p5go.Vector{X: 102, Y: 53}, Size: p5go.Vector{X: 12, Y: 20}}, p5go.Vector{X: 114, Y: 53}},
+	}
+	for _, tt := range tests {
+		a := NewAnimatedSprite(s)
+		a.Sheet.AddClip(tt.name, 10, true, tt.frame)
+		a.Play(tt.name)
+		a.Position, a.FlipX, a.FlipY = p5go.Vector{X: 100, Y: 50}, tt.flipX, tt.flipY
+		bounds := a.Bounds()
+		if want := (p5go.Rectangle{Position: a.Position, Size: p5go.Vector{X: 16, Y: 24}}); bounds != want {
+			t.Errorf("%s: Bounds = %v, want %v", tt.name, bounds, want)
+		}
+		d := newFakeDrawer()
+		a.Draw(d)
+		img := d.images[0]
+		if b := img.bounds(); !near(b.Position, tt.drawn.Position) || !near(b.Size, tt.drawn.Size) {
+			t.Errorf("%s: drawn at %v, want %v", tt.name, b, tt.drawn)
+		}
+		if b := img.bounds(); b.Position.X < bounds.Position.X-1e-9 || b.Max().X > bounds.Max().X+1e-9 ||
+			b.Position.Y < bounds.Position.Y-1e-9 || b.Max().Y > bounds.Max().Y+1e-9 {
+			t.Errorf("%s: drawn at %v, outside Bounds %v", tt.name, b, bounds)
+		}
+		if !near(img.corners[0], tt.sourceOrigin) {
+			t.Errorf("%s: source origin at %v, want %v", tt.name, img.corners[0], tt.sourceOrigin)
+		}
+	}
+}
+
+func TestEmptySheet(t *testing.T) {
+	for _, g := range []Grid{
+		{Width: 64, Height: 64},
+		{Width: 64, Height: 64, FrameWidth: 16, FrameHeight: -1},
+		{Width: 64, Height: 64, FrameWidth: 16, FrameHeight: 16, Spacing: -16},
+	} {
+		if s := FromGrid(nil, g); len(s.Frames) != 0 {
+			t.Errorf("FromGrid(%+v) has %d frames", g, len(s.Frames))
+		}
+	}
+	a := NewAnimatedSprite(NewSheet(nil, nil))
+	a.Sheet.AddClip("idle", 10, true, 0, 1)
+	a.Play("idle")
+	a.Update(1)
+	a.Position = p5go.Vector{X: 5, Y: 6}
+	if b := a.Bounds(); b != (p5go.Rectangle{Position: a.Position}) {
+		t.Errorf("Bounds of an empty sheet = %v", b)
+	}
+	d := newFakeDrawer()
+	a.Draw(d)
+	if len(d.images) != 0 {
+		t.Errorf("drew %d images of an empty sheet", len(d.images))
+	}
+}
+
+func TestFromGrid(t *testing.T) {
+	s := FromGrid(nil, Grid{Width: 70, Height: 40, FrameWidth: 16, FrameHeight: 16, Margin: 2, Spacing: 1})
+	if len(s.Frames) != 6 {
+		t.Fatalf("%d frames, want 6", len(s.Frames))
+	}
+	if r := s.Frames[4].Rect; r.Position != (p5go.Vector{X: 19, Y: 19}) || r.Size != (p5go.Vector{X: 16, Y: 16}) {
+		t.Errorf("frame 4 at %v", r)
+	}
+	if s := FromGrid(nil, Grid{Width: 70, Height: 40, FrameWidth: 16, FrameHeight: 16, Count: 3}); len(s.Frames) != 3 {
+		t.Errorf("%d frames with a count of 3", len(s.Frames))
+	}
+}
+
+// play returns the frame shown after each of n updates of dt.
+func play(a *AnimatedSprite, n int, dt float64) []int {
+	res := make([]int, n)
+	for i := range res {
+		a.Update(dt)
+		res[i] = a.Index()
+	}
+	return res
+}
+
+func TestUpdate(t *testing.T) {
+	s := FromGrid(nil, Grid{Width: 64, Height: 16, FrameWidth: 16, FrameHeight: 16})
+	s.AddClip("loop", 10, true, 0, 1, 2)
+	s.AddClip("bounce", 10, true, 0, 1, 2, 3).PingPong = true
+	s.AddClip("once", 10, false, 3, 2, 1)
+	s.AddClip("one", 10, true, 2).PingPong = true
+
+	tests := []struct {
+		clip string
+		want []int
+	}{
+		{"loop", []int{1, 2, 0, 1}},
+		{"bounce", []int{1, 2, 3, 2, 1, 0, 1, 2}},
+		{"one", []int{2, 2, 2}},
+	}
+	for _, tt := range tests {
+		a := NewAnimatedSprite(s)
+		a.Play(tt.clip)
+		if got := play(a, len(tt.want), 0.1); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: frames %v, want %v", tt.clip, got, tt.want)
+		}
+	}
+
+	// a clip that does not loop stops on its last frame and reports the end once
+	var ended []string
+	a := NewAnimatedSprite(s)
+	a.OnEnd = func(clip string) { ended = append(ended, clip) }
+	a.Play("once")
+	if got, want := play(a, 4, 0.1), []int{2, 1, 1, 1}; !slices.Equal(got, want) {
+		t.Errorf("once: frames %v, want %v", got, want)
+	}
+	if !a.Done() || !slices.Equal(ended, []string{"once"}) {
+		t.Errorf("Done = %v, ended %v", a.Done(), ended)
+	}
+	// a long update skips frames, speed scales time and playing the same clip does not restart it
+	a.Play("loop")
+	a.Speed = 2
+	if a.Update(0.25); a.Index() != 2 {
+		t.Errorf("after a long update at double speed frame %d, want 2", a.Index())
+	}
+	a.Play("loop")
+	if a.Index() != 2 {
+		t.Errorf("Play of the current clip restarted it")
+	}
+	a.Restart()
+	if a.Index() != 0 || a.Done() {
+		t.Errorf("Restart shows frame %d", a.Index())
+	}
+
+	// frame durations of the atlas take precedence over the clip
+	atlas, _ := ParseAtlas(nil, []byte(atlasHash))
+	a = NewAnimatedSprite(atlas)
+	a.Play("fwd")
+	if got, want := play(a, 4, 0.05), []int{1, 1, 2, 2}; !slices.Equal(got, want) {
+		t.Errorf("atlas durations: frames %v, want %v", got, want)
+	}
+}
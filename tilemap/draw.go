@@ -0,0 +1,173 @@
+package tilemap
+
+import (
+	"math"
+
+	"github.com/ryomak/p5go"
+	"github.com/ryomak/p5go/sprite"
+)
+
+// tinter is implemented by drawers that can tint images, such as p5go.Canvas. Layer opacity is
+// applied through it and reset by Pop.
+type tinter interface {
+	Tint(args ...any)
+}
+
+// Draw draws the visible layers of the map that overlap view, a rectangle in map pixels such as
+// the area seen by a camera. Only the tiles in view are drawn. Maps that are not orthogonal are
+// not drawn.
+func (m *Map) Draw(d sprite.Drawer, view p5go.Rectangle) {
+	if !m.orthogonal() {
+		return
+	}
+	m.drawLayers(d, view, m.Layers, p5go.Vector{}, 1)
+}
+
+// DrawLayer draws one layer of the map that overlaps view, even if it is hidden.
+func (m *Map) DrawLayer(d sprite.Drawer, view p5go.Rectangle, l *Layer) {
+	if !m.orthogonal() {
+		return
+	}
+	m.drawLayer(d, view, l, p5go.Vector{}, 1)
+}
+
+// orthogonal returns true for the maps Draw supports. Maps without an orientation are orthogonal.
+func (m *Map) orthogonal() bool {
+	return m.Orientation == "" || m.Orientation == "orthogonal"
+}
+
+func (m *Map) drawLayers(d sprite.Drawer, view p5go.Rectangle, layers []*Layer, offset p5go.Vector, opacity float64) {
+	for _, l := range layers {
+		if l.Visible {
+			m.drawLayer(d, view, l, offset, opacity)
+		}
+	}
+}
+
+func (m *Map) drawLayer(d sprite.Drawer, view p5go.Rectangle, l *Layer, offset p5go.Vector, opacity float64) {
+	offset = offset.Add(l.Offset)
+	opacity *= l.Opacity
+	if l.Type == Group {
+		m.drawLayers(d, view, l.Layers, offset, opacity)
+		return
+	}
+
+	d.Push()
+	if t, ok := d.(tinter); ok && opacity < 1 {
+		t.Tint(255, opacity*255)
+	}
+	d.Translate(offset.X, offset.Y)
+	view.Position = view.Position.Sub(offset)
+	switch l.Type {
+	case TileLayer:
+		m.drawTiles(d, view, l)
+	case ObjectGroup:
+		for _, o := range l.Objects {
+			if o.Visible && o.GID != 0 && overlaps(o.Bounds(), view) {
+				m.drawObject(d, o)
+			}
+		}
+	case ImageLayer:
+		if l.Image != nil {
+			d.Image(l.Image, 0, 0)
+		}
+	}
+	d.Pop()
+}
+
+func (m *Map) drawTiles(d sprite.Drawer, view p5go.Rectangle, l *Layer) {
+	// tiles larger than the grid are drawn from the bottom-left corner of their cell, so they reach
+	// into view from the cells to the left and below it
+	var extraCols, extraRows int
+	for _, t := range m.Tilesets {
+		extraCols = max(extraCols, int(math.Ceil((t.TileWidth+math.Abs(t.TileOffset.X))/m.TileWidth))-1)
+		extraRows = max(extraRows, int(math.Ceil((t.TileHeight+math.Abs(t.TileOffset.Y))/m.TileHeight))-1)
+	}
+	c0, r0 := m.Cell(view.Min())
+	c1, r1 := m.Cell(view.Max())
+	c0, r0 = max(c0-extraCols, l.StartX), max(r0, l.StartY)
+	c1, r1 = min(c1, l.StartX+l.Width-1), min(r1+extraRows, l.StartY+l.Height-1)
+	for row := r0; row <= r1; row++ {
+		for col := c0; col <= c1; col++ {
+			gid := l.At(col, row)
+			t := m.Tileset(gid)
+			if t == nil {
+				continue
+			}
+			x := float64(col)*m.TileWidth + t.TileOffset.X
+			y := float64(row+1)*m.TileHeight - t.TileHeight + t.TileOffset.Y
+			m.drawTile(d, t, gid, x, y, t.TileWidth, t.TileHeight)
+		}
+	}
+}
+
+// drawObject draws a tile object, scaled to its size and rotated around its bottom-left corner.
+func (m *Map) drawObject(d sprite.Drawer, o *Object) {
+	t := m.Tileset(o.GID)
+	if t == nil {
+		return
+	}
+	d.Push()
+	d.Translate(o.Position.X, o.Position.Y)
+	if o.Rotation != 0 {
+		d.Rotate(o.Rotation * math.Pi / 180)
+	}
+	m.drawTile(d, t, o.GID, 0, -o.Size.Y, o.Size.X, o.Size.Y)
+	d.Pop()
+}
+
+// drawTile draws the tile gid of t into the rectangle x, y, w, h, applying its flip flags and
+// animation.
+func (m *Map) drawTile(d sprite.Drawer, t *Tileset, gid uint32, x, y, w, h float64) {
+	if t.Image == nil || t.Columns <= 0 {
+		return
+	}
+	id := t.frame(int(gid&GIDMask-t.FirstGID), m.Time)
+	sx := t.Margin + float64(id%t.Columns)*(t.TileWidth+t.Spacing)
+	sy := t.Margin + float64(id/t.Columns)*(t.TileHeight+t.Spacing)
+
+	flags := gid &^ GIDMask
+	if flags&(FlipHorizontal|FlipVertical|FlipDiagonal) == 0 {
+		d.Image(t.Image, x, y, w, h, sx, sy, t.TileWidth, t.TileHeight)
+		return
+	}
+	// the diagonal flip swaps the axes, then the horizontal and vertical flips mirror them
+	a, b, c, e := 1.0, 0.0, 0.0, 1.0
+	if flags&FlipDiagonal != 0 {
+		a, b, c, e = 0, 1, 1, 0
+	}
+	if flags&FlipHorizontal != 0 {
+		a, c = -a, -c
+	}
+	if flags&FlipVertical != 0 {
+		b, e = -b, -e
+	}
+	d.Push()
+	d.Translate(x+w/2, y+h/2)
+	d.ApplyMatrix(a, b, c, e, 0, 0)
+	d.Image(t.Image, -w/2, -h/2, w, h, sx, sy, t.TileWidth, t.TileHeight)
+	d.Pop()
+}
+
+// frame returns the local tile ID shown for tile id at time seconds.
+func (t *Tileset) frame(id int, time float64) int {
+	tile := t.Tiles[id]
+	if tile == nil || len(tile.Animation) == 0 {
+		return id
+	}
+	var total float64
+	for _, f := range tile.Animation {
+		total += f.Duration
+	}
+	if total <= 0 {
+		return tile.Animation[0].TileID
+	}
+	time = math.Mod(time, total)
+	for _, f := range tile.Animation {
+		if time < f.Duration {
+			return f.TileID
+		}
+		time -= f.Duration
+	}
+	return tile.Animation[len(tile.Animation)-1].TileID
+}
@@ -0,0 +1,75 @@
+//go:build js && wasm
+
+package tilemap
+
+import (
+	"path"
+
+	"github.com/ryomak/p5go"
+)
+
+// Load loads a map saved by Tiled as JSON, its external tilesets and the images of its tilesets
+// and image layers, then passes the map to loaded. Paths in the files are resolved relative to the
+// file that holds them. Called from the preload handler, setup waits until everything is loaded.
+func Load(c *p5go.Canvas, mapPath string, loaded func(m *Map, err error)) {
+	c.LoadJSON(mapPath, func(data []byte, err error) {
+		if err != nil {
+			loaded(nil, err)
+			return
+		}
+		m, err := Parse(data)
+		if err != nil {
+			loaded(nil, err)
+			return
+		}
+		dir := path.Dir(mapPath)
+		walk(m.Layers, func(l *Layer) bool {
+			if l.Type == ImageLayer && l.ImagePath != "" {
+				l.Image = c.LoadImage(path.Join(dir, l.ImagePath))
+			}
+			return true
+		})
+
+		pending := 1 // released after the loop, so loaded runs once every tileset is in
+		var failed error
+		done := func() {
+			if pending--; pending == 0 {
+				if failed != nil {
+					loaded(nil, failed)
+					return
+				}
+				loaded(m, nil)
+			}
+		}
+		for i, t := range m.Tilesets {
+			if t.Source == "" {
+				loadImage(c, t, dir)
+				continue
+			}
+			pending++
+			source := path.Join(dir, t.Source)
+			c.LoadJSON(source, func(data []byte, err error) {
+				defer done()
+				if err != nil {
+					failed = err
+					return
+				}
+				ts, err := ParseTileset(data)
+				if err != nil {
+					failed = err
+					return
+				}
+				ts.FirstGID, ts.Source = t.FirstGID, t.Source
+				loadImage(c, ts, path.Dir(source))
+				m.Tilesets[i] = ts
+			})
+		}
+		done()
+	})
+}
+
+func loadImage(c *p5go.Canvas, t *Tileset, dir string) {
+	if t.ImagePath != "" {
+		t.Image = c.LoadImage(path.Join(dir, t.ImagePath))
+	}
+}
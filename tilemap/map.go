@@ -0,0 +1,228 @@
+package tilemap
+
+import (
+	"math"
+
+	"github.com/ryomak/p5go"
+)
+
+// Update advances the time of animated tiles by dt seconds.
+func (m *Map) Update(dt float64) {
+	m.Time += dt
+}
+
+// Bounds returns the rectangle covered by the map in pixels.
+func (m *Map) Bounds() p5go.Rectangle {
+	return p5go.Rectangle{Size: p5go.Vector{X: float64(m.Width) * m.TileWidth, Y: float64(m.Height) * m.TileHeight}}
+}
+
+// Layer returns the first layer named name, searching inside groups, or nil.
+func (m *Map) Layer(name string) *Layer {
+	var found *Layer
+	walk(m.Layers, func(l *Layer) bool {
+		if l.Name == name {
+			found = l
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+// Objects returns the objects of every object group whose Type is typ, or all of them if typ is
+// empty.
+func (m *Map) Objects(typ string) []*Object {
+	var objects []*Object
+	walk(m.Layers, func(l *Layer) bool {
+		for _, o := range l.Objects {
+			if typ == "" || o.Type == typ {
+				objects = append(objects, o)
+			}
+		}
+		return true
+	})
+	return objects
+}
+
+// walk calls f for the layers and the layers of groups in drawing order until f returns false.
+func walk(layers []*Layer, f func(l *Layer) bool) bool {
+	for _, l := range layers {
+		if !f(l) || !walk(l.Layers, f) {
+			return false
+		}
+	}
+	return true
+}
+
+// Tileset returns the tileset holding the global tile ID gid, or nil.
+func (m *Map) Tileset(gid uint32) *Tileset {
+	gid &= GIDMask
+	if gid == 0 {
+		return nil
+	}
+	var found *Tileset
+	for _, t := range m.Tilesets {
+		if t.FirstGID <= gid && (found == nil || t.FirstGID > found.FirstGID) {
+			found = t
+		}
+	}
+	return found
+}
+
+// Tile returns the metadata of the global tile ID gid, or nil if it has none.
+func (m *Map) Tile(gid uint32) *Tile {
+	t := m.Tileset(gid)
+	if t == nil {
+		return nil
+	}
+	return t.Tiles[int(gid&GIDMask-t.FirstGID)]
+}
+
+// Cell returns the column and row of the tile at p in map pixels.
+func (m *Map) Cell(p p5go.Vector) (col, row int) {
+	return int(math.Floor(p.X / m.TileWidth)), int(math.Floor(p.Y / m.TileHeight))
+}
+
+// TileRect returns the rectangle of the tile at col, row in map pixels.
+func (m *Map) TileRect(col, row int) p5go.Rectangle {
+	return p5go.Rectangle{
+		Position: p5go.Vector{X: float64(col) * m.TileWidth, Y: float64(row) * m.TileHeight},
+		Size:     p5go.Vector{X: m.TileWidth, Y: m.TileHeight},
+	}
+}
+
+// At returns the global tile ID at col, row, or 0 outside the layer.
+func (l *Layer) At(col, row int) uint32 {
+	col, row = col-l.StartX, row-l.StartY
+	if col < 0 || row < 0 || col >= l.Width || row >= l.Height || len(l.Data) < l.Width*l.Height {
+		return 0
+	}
+	return l.Data[row*l.Width+col]
+}
+
+// Set sets the global tile ID at col, row. Tiles outside the layer are ignored.
+func (l *Layer) Set(col, row int, gid uint32) {
+	col, row = col-l.StartX, row-l.StartY
+	if col < 0 || row < 0 || col >= l.Width || row >= l.Height || len(l.Data) < l.Width*l.Height {
+		return
+	}
+	l.Data[row*l.Width+col] = gid
+}
+
+// Colliders appends to dst the collision rectangles of the tiles of layer overlapping area, in map
+// pixels, and returns the extended slice. A tile collides if solid returns true for its global ID,
+// or if it is not empty when solid is nil. Tiles with collision shapes set in Tiled give the
+// bounding boxes of their shapes; the others give their whole cell. Rectangles that only touch
+// area do not overlap it.
+func (m *Map) Colliders(layer *Layer, area p5go.Rectangle, solid func(gid uint32) bool, dst []p5go.Rectangle) []p5go.Rectangle {
+	area.Position = area.Position.Sub(layer.Offset)
+	c0, r0 := m.Cell(area.Min())
+	c1, r1 := m.Cell(area.Max())
+	c0, r0 = max(c0, layer.StartX), max(r0, layer.StartY)
+	c1, r1 = min(c1, layer.StartX+layer.Width-1), min(r1, layer.StartY+layer.Height-1)
+	for row := r0; row <= r1; row++ {
+		for col := c0; col <= c1; col++ {
+			gid := layer.At(col, row)
+			if gid == 0 || solid != nil && !solid(gid) {
+				continue
+			}
+			cell := m.TileRect(col, row)
+			tile := m.Tile(gid)
+			if tile == nil || len(tile.Objects) == 0 {
+				if overlaps(cell, area) {
+					cell.Position = cell.Position.Add(layer.Offset)
+					dst = append(dst, cell)
+				}
+				continue
+			}
+			for _, o := range tile.Objects {
+				r := flipRect(o.Bounds(), gid, cell.Size)
+				r.Position = r.Position.Add(cell.Position)
+				if overlaps(r, area) {
+					r.Position = r.Position.Add(layer.Offset)
+					dst = append(dst, r)
+				}
+			}
+		}
+	}
+	return dst
+}
+
+// Collides returns true if r overlaps a solid tile of layer. See Colliders for solid.
+func (m *Map) Collides(layer *Layer, r p5go.Rectangle, solid func(gid uint32) bool) bool {
+	return len(m.Colliders(layer, r, solid, nil)) > 0
+}
+
+// CollidesCircle returns true if c overlaps a solid tile of layer. See Colliders for solid.
+func (m *Map) CollidesCircle(layer *Layer, c p5go.Circle, solid func(gid uint32) bool) bool {
+	for _, r := range m.Colliders(layer, c.Bounds(), solid, nil) {
+		if r.IntersectsCircle(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// overlaps returns true if the rectangles share some area.
+func overlaps(a, b p5go.Rectangle) bool {
+	r, ok := a.Intersection(b)
+	return ok && r.Size.X > 0 && r.Size.Y > 0
+}
+
+// flipRect mirrors r inside a tile of size size by the flip flags of gid.
+func flipRect(r p5go.Rectangle, gid uint32, size p5go.Vector) p5go.Rectangle {
+	if gid&FlipDiagonal != 0 {
+		r.Position.X, r.Position.Y = r.Position.Y, r.Position.X
+		r.Size.X, r.Size.Y = r.Size.Y, r.Size.X
+	}
+	if gid&FlipHorizontal != 0 {
+		r.Position.X = size.X - r.Position.X - r.Size.X
+	}
+	if gid&FlipVertical != 0 {
+		r.Position.Y = size.Y - r.Position.Y - r.Size.Y
+	}
+	return r
+}
+
+// Points returns the points of a polygon or polyline object in map pixels, ignoring Rotation.
+func (o *Object) Points() []p5go.Vector {
+	src := o.Polygon
+	if src == nil {
+		src = o.Polyline
+	}
+	points := make([]p5go.Vector, len(src))
+	for i, p := range src {
+		points[i] = o.Position.Add(p)
+	}
+	return points
+}
+
+// Bounds returns the bounding rectangle of the object in map pixels, ignoring Rotation.
+func (o *Object) Bounds() p5go.Rectangle {
+	switch {
+	case o.Polygon != nil || o.Polyline != nil:
+		return p5go.BoundingBox(o.Points())
+	case o.GID != 0:
+		return p5go.Rectangle{Position: p5go.Vector{X: o.Position.X, Y: o.Position.Y - o.Size.Y}, Size: o.Size}
+	}
+	return p5go.Rectangle{Position: o.Position, Size: o.Size}
+}
+
+// Contains returns true if p is inside the rectangle, ellipse or polygon of the object, ignoring
+// Rotation. Points and polylines contain nothing.
+func (o *Object) Contains(p p5go.Vector) bool {
+	switch {
+	case o.Point || o.Polyline != nil:
+		return false
+	case o.Polygon != nil:
+		return p5go.PointInPolygon(p, o.Points())
+	case o.Ellipse:
+		if o.Size.X <= 0 || o.Size.Y <= 0 {
+			return false
+		}
+		c := o.Bounds().Center()
+		dx, dy := (p.X-c.X)/(o.Size.X/2), (p.Y-c.Y)/(o.Size.Y/2)
+		return dx*dx+dy*dy <= 1
+	}
+	return o.Bounds().Contains(p)
+}
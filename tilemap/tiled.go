@@ -0,0 +1,447 @@
+// Package tilemap loads maps made with the Tiled editor in its JSON format, draws the tiles in view
+// and answers collision queries against them.
+package tilemap
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/ryomak/p5go"
+)
+
+// Flags stored in the high bits of a global tile ID.
+const (
+	FlipHorizontal uint32 = 0x80000000
+	FlipVertical   uint32 = 0x40000000
+	FlipDiagonal   uint32 = 0x20000000
+	RotateHex120   uint32 = 0x10000000
+
+	// GIDMask clears the flags of a global tile ID.
+	GIDMask = ^(FlipHorizontal | FlipVertical | FlipDiagonal | RotateHex120)
+)
+
+// Layer types.
+const (
+	TileLayer   = "tilelayer"
+	ObjectGroup = "objectgroup"
+	ImageLayer  = "imagelayer"
+	Group       = "group"
+)
+
+// Map is a Tiled map. Only orthogonal maps are drawn.
+type Map struct {
+	Width, Height         int // in tiles
+	TileWidth, TileHeight float64
+	Orientation           string
+	Infinite              bool
+	BackgroundColor       string
+	Properties            Properties
+	Tilesets              []*Tileset
+	Layers                []*Layer
+	Time                  float64 // seconds, advanced by Update to play animated tiles
+}
+
+// Tileset is a set of tiles cut from one image.
+type Tileset struct {
+	FirstGID                uint32
+	Source                  string // file of an external tileset, empty if it is embedded in the map
+	Name                    string
+	Image                   any    // the loaded image, set by Load
+	ImagePath               string // relative to the file holding the tileset
+	ImageWidth, ImageHeight float64
+	TileWidth, TileHeight   float64
+	Margin, Spacing         float64
+	Columns, TileCount      int
+	TileOffset              p5go.Vector
+	Properties              Properties
+	Tiles                   map[int]*Tile // tiles with metadata, by local ID
+}
+
+// Tile holds the metadata of a tile in a tileset.
+type Tile struct {
+	ID         int
+	Type       string
+	Properties Properties
+	Objects    []*Object // collision shapes relative to the top-left corner of the tile
+	Animation  []TileFrame
+}
+
+// TileFrame is a frame of an animated tile.
+type TileFrame struct {
+	TileID   int     // local ID in the same tileset
+	Duration float64 // seconds
+}
+
+// Layer is a tile layer, object group, image layer or group of layers, as given by Type.
+type Layer struct {
+	ID         int
+	Name       string
+	Type       string
+	Class      string
+	Visible    bool
+	Opacity    float64
+	Offset     p5go.Vector
+	Properties Properties
+
+	// Tile layers hold Width×Height global tile IDs, row by row, starting at tile StartX, StartY.
+	StartX, StartY int
+	Width, Height  int
+	Data           []uint32
+
+	Objects []*Object // object groups
+
+	Image     any    // image layers, set by Load
+	ImagePath string // relative to the map file
+
+	Layers []*Layer // groups
+}
+
+// Object is a shape or tile placed in an object group. Polygon and Polyline points are relative to
+// Position.
+type Object struct {
+	ID             int
+	Name           string
+	Type           string
+	Position       p5go.Vector
+	Size           p5go.Vector
+	Rotation       float64 // degrees clockwise
+	GID            uint32  // tile objects, whose Position is their bottom-left corner
+	Visible        bool
+	Point, Ellipse bool
+	Polygon        []p5go.Vector
+	Polyline       []p5go.Vector
+	Text           string
+	Properties     Properties
+}
+
+// Properties holds the custom properties of a map, tileset, tile, layer or object.
+// Numbers are float64 and class properties are map[string]any.
+type Properties map[string]any
+
+// String returns the property name as a string.
+func (p Properties) String(name string) string {
+	s, _ := p[name].(string)
+	return s
+}
+
+// Float returns the property name as a number.
+func (p Properties) Float(name string) float64 {
+	f, _ := p[name].(float64)
+	return f
+}
+
+// Int returns the property name as an integer.
+func (p Properties) Int(name string) int {
+	return int(p.Float(name))
+}
+
+// Bool returns the property name as a bool.
+func (p Properties) Bool(name string) bool {
+	b, _ := p[name].(bool)
+	return b
+}
+
+type rawProperty struct {
+	Name  string
+	Value any
+}
+
+type rawPoint struct {
+	X, Y float64
+}
+
+type rawObject struct {
+	ID                  int
+	Name, Type, Class   string
+	X, Y, Width, Height float64
+	Rotation            float64
+	GID                 uint32
+	Visible             *bool
+	Point, Ellipse      bool
+	Polygon, Polyline   []rawPoint
+	Text                *struct{ Text string }
+	Properties          []rawProperty
+}
+
+type rawChunk struct {
+	Data          json.RawMessage
+	X, Y          int
+	Width, Height int
+}
+
+type rawLayer struct {
+	ID                int
+	Name, Type, Class string
+	Visible           *bool
+	Opacity           *float64
+	OffsetX, OffsetY  float64
+	Properties        []rawProperty
+	StartX, StartY    int
+	Width, Height     int
+	Encoding          string
+	Compression       string
+	Data              json.RawMessage
+	Chunks            []rawChunk
+	Objects           []rawObject
+	Image             string
+	Layers            []rawLayer
+}
+
+type rawTileset struct {
+	FirstGID                uint32
+	Source                  string
+	Name                    string
+	Image                   string
+	ImageWidth, ImageHeight float64
+	TileWidth, TileHeight   float64
+	Margin, Spacing         float64
+	Columns, TileCount      int
+	TileOffset              rawPoint
+	Properties              []rawProperty
+	Tiles                   []struct {
+		ID          int
+		Type, Class string
+		Properties  []rawProperty
+		ObjectGroup *struct{ Objects []rawObject }
+		Animation   []struct {
+			TileID   int
+			Duration float64
+		}
+	}
+}
+
+type rawMap struct {
+	Width, Height         int
+	TileWidth, TileHeight float64
+	Orientation           string
+	Infinite              bool
+	BackgroundColor       string
+	Properties            []rawProperty
+	Tilesets              []rawTileset
+	Layers                []rawLayer
+}
+
+// Parse parses a map saved by Tiled as JSON. External tilesets only have their FirstGID and Source
+// set; Load fetches them, or parse them with ParseTileset. Only orthogonal maps are supported.
+func Parse(data []byte) (*Map, error) {
+	var raw rawMap
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("tilemap: parse map: %w", err)
+	}
+	if o := raw.Orientation; o != "" && o != "orthogonal" {
+		return nil, fmt.Errorf("tilemap: unsupported orientation %q", o)
+	}
+	m := &Map{
+		Width:           raw.Width,
+		Height:          raw.Height,
+		TileWidth:       raw.TileWidth,
+		TileHeight:      raw.TileHeight,
+		Orientation:     raw.Orientation,
+		Infinite:        raw.Infinite,
+		BackgroundColor: raw.BackgroundColor,
+		Properties:      properties(raw.Properties),
+	}
+	for _, t := range raw.Tilesets {
+		m.Tilesets = append(m.Tilesets, tileset(t))
+	}
+	for _, l := range raw.Layers {
+		layer, err := parseLayer(l)
+		if err != nil {
+			return nil, err
+		}
+		m.Layers = append(m.Layers, layer)
+	}
+	return m, nil
+}
+
+// ParseTileset parses a tileset saved by Tiled as JSON.
+func ParseTileset(data []byte) (*Tileset, error) {
+	var raw rawTileset
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("tilemap: parse tileset: %w", err)
+	}
+	return tileset(raw), nil
+}
+
+func tileset(raw rawTileset) *Tileset {
+	t := &Tileset{
+		FirstGID:    raw.FirstGID,
+		Source:      raw.Source,
+		Name:        raw.Name,
+		ImagePath:   raw.Image,
+		ImageWidth:  raw.ImageWidth,
+		ImageHeight: raw.ImageHeight,
+		TileWidth:   raw.TileWidth,
+		TileHeight:  raw.TileHeight,
+		Margin:      raw.Margin,
+		Spacing:     raw.Spacing,
+		Columns:     raw.Columns,
+		TileCount:   raw.TileCount,
+		TileOffset:  p5go.Vector{X: raw.TileOffset.X, Y: raw.TileOffset.Y},
+		Properties:  properties(raw.Properties),
+		Tiles:       map[int]*Tile{},
+	}
+	for _, rt := range raw.Tiles {
+		tile := &Tile{ID: rt.ID, Type: rt.Type, Properties: properties(rt.Properties)}
+		if rt.Class != "" {
+			tile.Type = rt.Class
+		}
+		if rt.ObjectGroup != nil {
+			for _, o := range rt.ObjectGroup.Objects {
+				tile.Objects = append(tile.Objects, object(o))
+			}
+		}
+		for _, f := range rt.Animation {
+			tile.Animation = append(tile.Animation, TileFrame{TileID: f.TileID, Duration: f.Duration / 1000})
+		}
+		t.Tiles[rt.ID] = tile
+	}
+	return t
+}
+
+func parseLayer(raw rawLayer) (*Layer, error) {
+	l := &Layer{
+		ID:         raw.ID,
+		Name:       raw.Name,
+		Type:       raw.Type,
+		Class:      raw.Class,
+		Visible:    raw.Visible == nil || *raw.Visible,
+		Opacity:    1,
+		Offset:     p5go.Vector{X: raw.OffsetX, Y: raw.OffsetY},
+		Properties: properties(raw.Properties),
+		StartX:     raw.StartX,
+		StartY:     raw.StartY,
+		Width:      raw.Width,
+		Height:     raw.Height,
+		ImagePath:  raw.Image,
+	}
+	if raw.Opacity != nil {
+		l.Opacity = *raw.Opacity
+	}
+
+	switch raw.Type {
+	case TileLayer:
+		if len(raw.Chunks) == 0 {
+			data, err := tileData(raw.Data, raw.Encoding, raw.Compression, raw.Width*raw.Height)
+			if err != nil {
+				return nil, fmt.Errorf("tilemap: layer %q: %w", raw.Name, err)
+			}
+			l.Data = data
+			break
+		}
+		// infinite maps store the layer in chunks
+		l.Data = make([]uint32, raw.Width*raw.Height)
+		for _, c := range raw.Chunks {
+			data, err := tileData(c.Data, raw.Encoding, raw.Compression, c.Width*c.Height)
+			if err != nil {
+				return nil, fmt.Errorf("tilemap: layer %q: %w", raw.Name, err)
+			}
+			for i, gid := range data {
+				l.Set(c.X+i%c.Width, c.Y+i/c.Width, gid)
+			}
+		}
+	case ObjectGroup:
+		for _, o := range raw.Objects {
+			l.Objects = append(l.Objects, object(o))
+		}
+	case Group:
+		for _, child := range raw.Layers {
+			c, err := parseLayer(child)
+			if err != nil {
+				return nil, err
+			}
+			l.Layers = append(l.Layers, c)
+		}
+	}
+	return l, nil
+}
+
+// tileData decodes the global tile IDs of a layer or chunk, written as a JSON array or as base64
+// of little-endian uint32s, optionally compressed.
+func tileData(raw json.RawMessage, encoding, compression string, n int) ([]uint32, error) {
+	if encoding != "base64" {
+		var data []uint32
+		if err := json.Unmarshal(raw, &data); err != nil {
+			return nil, err
+		}
+		return data, nil
+	}
+
+	var text string
+	if err := json.Unmarshal(raw, &text); err != nil {
+		return nil, err
+	}
+	b, err := base64.StdEncoding.DecodeString(text)
+	if err != nil {
+		return nil, err
+	}
+	var r io.Reader = bytes.NewReader(b)
+	switch compression {
+	case "":
+	case "zlib":
+		if r, err = zlib.NewReader(r); err != nil {
+			return nil, err
+		}
+	case "gzip":
+		if r, err = gzip.NewReader(r); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported compression %q", compression)
+	}
+	data := make([]uint32, n)
+	if err := binary.Read(r, binary.LittleEndian, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func object(raw rawObject) *Object {
+	o := &Object{
+		ID:         raw.ID,
+		Name:       raw.Name,
+		Type:       raw.Type,
+		Position:   p5go.Vector{X: raw.X, Y: raw.Y},
+		Size:       p5go.Vector{X: raw.Width, Y: raw.Height},
+		Rotation:   raw.Rotation,
+		GID:        raw.GID,
+		Visible:    raw.Visible == nil || *raw.Visible,
+		Point:      raw.Point,
+		Ellipse:    raw.Ellipse,
+		Polygon:    points(raw.Polygon),
+		Polyline:   points(raw.Polyline),
+		Properties: properties(raw.Properties),
+	}
+	if raw.Class != "" {
+		o.Type = raw.Class
+	}
+	if raw.Text != nil {
+		o.Text = raw.Text.Text
+	}
+	return o
+}
+
+func points(raw []rawPoint) []p5go.Vector {
+	if raw == nil {
+		return nil
+	}
+	points := make([]p5go.Vector, len(raw))
+	for i, p := range raw {
+		points[i] = p5go.Vector{X: p.X, Y: p.Y}
+	}
+	return points
+}
+
+func properties(raw []rawProperty) Properties {
+	p := Properties{}
+	for _, r := range raw {
+		p[r.Name] = r.Value
+	}
+	return p
+}
@@ -0,0 +1,319 @@
+package tilemap
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ryomak/p5go"
+)
+
+// encode writes gids as base64 of little-endian uint32s compressed with compression.
+func encode(t *testing.T, gids []uint32, compression string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	var w io.Writer = &buf
+	var c io.Closer
+	switch compression {
+	case "zlib":
+		z := zlib.NewWriter(&buf)
+		w, c = z, z
+	case "gzip":
+		z := gzip.NewWriter(&buf)
+		w, c = z, z
+	}
+	if err := binary.Write(w, binary.LittleEndian, gids); err != nil {
+		t.Fatal(err)
+	}
+	if c != nil {
+		c.Close()
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+// testMap returns a 3×2 map of 16 pixel tiles with one tile layer holding data.
+func testMap(layer string) string {
+	return `{
+		"width": 3, "height": 2, "tilewidth": 16, "tileheight": 16, "orientation": "orthogonal",
+		"properties": [{"name": "title", "type": "string", "value": "test"}],
+		"tilesets": [
+			{"firstgid": 1, "name": "ground", "image": "ground.png", "imagewidth": 64, "imageheight": 32,
+				"tilewidth": 16, "tileheight": 16, "columns": 4, "tilecount": 8,
+				"tiles": [
+					{"id": 1, "class": "wall", "properties": [{"name": "solid", "type": "bool", "value": true}],
+						"objectgroup": {"objects": [{"id": 1, "x": 0, "y": 8, "width": 4, "height": 8}]}},
+					{"id": 2, "animation": [{"tileid": 2, "duration": 100}, {"tileid": 3, "duration": 200}]}
+				]},
+			{"firstgid": 9, "source": "trees.json"}
+		],
+		"layers": [` + layer + `]
+	}`
+}
+
+var want = []uint32{1, 0, 2, 3 | FlipHorizontal, 2 | FlipVertical | FlipDiagonal, 9}
+
+func TestParseTileData(t *testing.T) {
+	layers := map[string]string{
+		"array": `{"type": "tilelayer", "name": "l", "width": 3, "height": 2, "data": [1, 0, 2, 2147483651, 1610612738, 9]}`,
+		"csv":   `{"type": "tilelayer", "name": "l", "width": 3, "height": 2, "encoding": "csv", "data": [1, 0, 2, 2147483651, 1610612738, 9]}`,
+	}
+	for _, compression := range []string{"", "zlib", "gzip"} {
+		layers["base64 "+compression] = fmt.Sprintf(`{"type": "tilelayer", "name": "l", "width": 3, "height": 2,
+			"encoding": "base64", "compression": %q, "data": %q}`, compression, encode(t, want, compression))
+	}
+	for name, layer := range layers {
+		m, err := Parse([]byte(testMap(layer)))
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if l := m.Layer("l"); l == nil || !slices.Equal(l.Data, want) {
+			t.Errorf("%s: data = %v, want %v", name, l.Data, want)
+		}
+	}
+
+	for name, layer := range map[string]string{
+		"compression": `{"type": "tilelayer", "width": 1, "height": 1, "encoding": "base64", "compression": "zstd", "data": "AQAAAA=="}`,
+		"short data":  `{"type": "tilelayer", "width": 2, "height": 1, "encoding": "base64", "data": "AQAAAA=="}`,
+		"base64":      `{"type": "tilelayer", "width": 1, "height": 1, "encoding": "base64", "data": "!"}`,
+	} {
+		if _, err := Parse([]byte(testMap(layer))); err == nil {
+			t.Errorf("%s: no error", name)
+		}
+	}
+}
+
+func TestParseChunks(t *testing.T) {
+	layer := fmt.Sprintf(`{"type": "tilelayer", "name": "l", "startx": -2, "starty": 0, "width": 4, "height": 2,
+		"encoding": "base64", "compression": "zlib", "chunks": [
+			{"x": -2, "y": 0, "width": 2, "height": 2, "data": %q},
+			{"x": 0, "y": 0, "width": 2, "height": 2, "data": %q}
+		]}`, encode(t, []uint32{1, 2, 3, 4}, "zlib"), encode(t, []uint32{5, 6, 7, 8}, "zlib"))
+	m, err := Parse([]byte(testMap(layer)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := m.Layer("l")
+	if !slices.Equal(l.Data, []uint32{1, 2, 5, 6, 3, 4, 7, 8}) {
+		t.Errorf("data = %v", l.Data)
+	}
+	if l.At(-2, 0) != 1 || l.At(1, 1) != 8 || l.At(2, 0) != 0 || l.At(-3, 0) != 0 {
+		t.Errorf("At outside or at the corners of the chunks is wrong")
+	}
+}
+
+func TestParseMetadata(t *testing.T) {
+	layers := `{"type": "tilelayer", "name": "ground", "width": 3, "height": 2, "data": [1, 0, 2, 2147483651, 1610612738, 9]},
+		{"type": "group", "name": "things", "visible": false, "opacity": 0.5, "layers": [
+			{"type": "objectgroup", "name": "spawns", "offsetx": 4, "objects": [
+				{"id": 1, "name": "start", "type": "spawn", "x": 8, "y": 8, "point": true},
+				{"id": 2, "class": "zone", "x": 0, "y": 0, "width": 10, "height": 10, "ellipse": true},
+				{"id": 3, "gid": 2, "x": 16, "y": 32, "width": 16, "height": 16},
+				{"id": 4, "polygon": [{"x": 0, "y": 0}, {"x": 10, "y": 0}, {"x": 0, "y": 10}], "x": 20, "y": 0}
+			]}
+		]}`
+	m, err := Parse([]byte(testMap(layers)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Properties.String("title") != "test" || m.Bounds().Size != (p5go.Vector{X: 48, Y: 32}) {
+		t.Errorf("map = %+v", m)
+	}
+	if ts := m.Tilesets[1]; ts.Source != "trees.json" || ts.FirstGID != 9 {
+		t.Errorf("external tileset = %+v", ts)
+	}
+	if m.Tileset(9) != m.Tilesets[1] || m.Tileset(8|FlipHorizontal) != m.Tilesets[0] || m.Tileset(0) != nil {
+		t.Errorf("Tileset picks the wrong tileset")
+	}
+	tile := m.Tile(2 | FlipVertical)
+	if tile == nil || tile.Type != "wall" || !tile.Properties.Bool("solid") || len(tile.Objects) != 1 {
+		t.Fatalf("tile = %+v", tile)
+	}
+	if anim := m.Tile(3).Animation; len(anim) != 2 || anim[1] != (TileFrame{TileID: 3, Duration: 0.2}) {
+		t.Errorf("animation = %+v", anim)
+	}
+
+	group := m.Layer("things")
+	if group == nil || group.Visible || group.Opacity != 0.5 || m.Layer("spawns") == nil {
+		t.Fatalf("group = %+v", group)
+	}
+	if got := m.Objects("spawn"); len(got) != 1 || got[0].Name != "start" || !got[0].Point {
+		t.Errorf("spawn objects = %+v", got)
+	}
+	objects := m.Objects("")
+	if len(objects) != 4 {
+		t.Fatalf("%d objects, want 4", len(objects))
+	}
+	zone, tileObject, polygon := objects[1], objects[2], objects[3]
+	if zone.Type != "zone" || !zone.Contains(p5go.Vector{X: 5, Y: 5}) || zone.Contains(p5go.Vector{X: 0.5, Y: 0.5}) {
+		t.Errorf("ellipse = %+v", zone)
+	}
+	if b := tileObject.Bounds(); b.Position != (p5go.Vector{X: 16, Y: 16}) {
+		t.Errorf("tile object bounds = %v, want its bottom-left corner at its position", b)
+	}
+	if !polygon.Contains(p5go.Vector{X: 22, Y: 2}) || polygon.Contains(p5go.Vector{X: 29, Y: 9}) {
+		t.Errorf("polygon = %+v", polygon.Points())
+	}
+}
+
+func TestColliders(t *testing.T) {
+	// the wall tile collides with its shape at the bottom left of the cell, 4×8 pixels
+	layer := `{"type": "tilelayer", "name": "l", "width": 3, "height": 2, "offsetx": 100, "data": [1, 2, 0, 0, 0, 0]}`
+	m, err := Parse([]byte(testMap(layer)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := m.Layer("l")
+	shape := func(gid uint32) p5go.Rectangle {
+		l.Data[1] = gid
+		r := m.Colliders(l, p5go.Rectangle{Position: p5go.Vector{X: 116}, Size: p5go.Vector{X: 16, Y: 16}}, nil, nil)
+		if len(r) != 1 {
+			t.Fatalf("gid %x: %d colliders, want 1", gid, len(r))
+		}
+		return r[0]
+	}
+	rect := func(x, y, w, h float64) p5go.Rectangle {
+		return p5go.Rectangle{Position: p5go.Vector{X: x, Y: y}, Size: p5go.Vector{X: w, Y: h}}
+	}
+	tests := []struct {
+		name string
+		gid  uint32
+		want p5go.Rectangle
+	}{
+		{"plain", 2, rect(116, 8, 4, 8)},
+		{"horizontal", 2 | FlipHorizontal, rect(128, 8, 4, 8)},
+		{"vertical", 2 | FlipVertical, rect(116, 0, 4, 8)},
+		{"diagonal", 2 | FlipDiagonal, rect(124, 0, 8, 4)},
+		{"rotated clockwise", 2 | FlipDiagonal | FlipHorizontal, rect(116, 0, 8, 4)},
+		// a tile without shapes collides with its whole cell
+		{"no shape", 3, rect(116, 0, 16, 16)},
+	}
+	for _, tt := range tests {
+		if got := shape(tt.gid); got != tt.want {
+			t.Errorf("%s: collider %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	l.Data[1] = 2
+	solid := func(gid uint32) bool { return m.Tile(gid) != nil && m.Tile(gid).Properties.Bool("solid") }
+	if got := m.Colliders(l, rect(100, 0, 48, 32), solid, nil); len(got) != 1 {
+		t.Errorf("solid colliders = %v, want the wall only", got)
+	}
+	// touching a collider is not colliding
+	if m.Collides(l, rect(120, 0, 10, 10), nil) || !m.Collides(l, rect(119, 7, 2, 2), nil) {
+		t.Errorf("Collides counts touching edges or misses an overlap")
+	}
+	if !m.CollidesCircle(l, p5go.Circle{Position: p5go.Vector{X: 118, Y: 12}, Diameter: 2}, nil) ||
+		m.CollidesCircle(l, p5go.Circle{Position: p5go.Vector{X: 128, Y: 4}, Diameter: 4}, nil) {
+		t.Errorf("CollidesCircle is wrong")
+	}
+	// an area far larger than the layer only visits its cells
+	huge := rect(-1e12, -1e12, 2e12, 2e12)
+	if got := m.Colliders(l, huge, nil, nil); len(got) != 2 {
+		t.Errorf("%d colliders in a huge area, want 2", len(got))
+	}
+}
+
+// recorder is a sprite.Drawer recording where images are drawn.
+type recorder struct{ drawn []p5go.Vector }
+
+func (r *recorder) Push()                                {}
+func (r *recorder) Pop()                                 {}
+func (r *recorder) Translate(x, y float64, z ...float64) {}
+func (r *recorder) Rotate(angle float64)                 {}
+func (r *recorder) ApplyMatrix(a, b, c, d, e, f float64) {}
+func (r *recorder) Image(img any, opts ...any) {
+	r.drawn = append(r.drawn, p5go.Vector{X: opts[0].(float64), Y: opts[1].(float64)})
+}
+
+func TestParseOrientation(t *testing.T) {
+	for _, orientation := range []string{"orthogonal", ""} {
+		doc := strings.Replace(testMap(""), `"orthogonal"`, `"`+orientation+`"`, 1)
+		if _, err := Parse([]byte(doc)); err != nil {
+			t.Errorf("%q map: %v", orientation, err)
+		}
+	}
+	for _, orientation := range []string{"isometric", "staggered", "hexagonal"} {
+		doc := strings.Replace(testMap(""), `"orthogonal"`, `"`+orientation+`"`, 1)
+		if _, err := Parse([]byte(doc)); err == nil {
+			t.Errorf("%q map parsed without an error", orientation)
+		}
+	}
+}
+
+func TestDrawOrientation(t *testing.T) {
+	layer := `{"type": "tilelayer", "name": "l", "width": 3, "height": 2, "data": [1, 0, 2, 3, 4, 5]}`
+	m, err := Parse([]byte(testMap(layer)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.Tilesets[0].Image = "ground"
+	view := p5go.Rectangle{Size: p5go.Vector{X: 1000, Y: 1000}}
+	// maps built or changed in code are not drawn either unless they are orthogonal
+	for _, orientation := range []string{"orthogonal", "", "isometric", "staggered", "hexagonal"} {
+		m.Orientation = orientation
+		r := &recorder{}
+		m.Draw(r, view)
+		m.DrawLayer(r, view, m.Layer("l"))
+		want := 10
+		if orientation != "orthogonal" && orientation != "" {
+			want = 0
+		}
+		if len(r.drawn) != want {
+			t.Errorf("%q map drew %d images, want %d", orientation, len(r.drawn), want)
+		}
+	}
+}
+
+func TestDrawView(t *testing.T) {
+	// a 6×4 layer filled with tile 1
+	data := strings.TrimSuffix(strings.Repeat("1, ", 24), ", ")
+	layer := `{"type": "tilelayer", "name": "l", "width": 6, "height": 4, "data": [` + data + `]}`
+	rect := func(x, y, w, h float64) p5go.Rectangle {
+		return p5go.Rectangle{Position: p5go.Vector{X: x, Y: y}, Size: p5go.Vector{X: w, Y: h}}
+	}
+	type cell struct{ col, row int }
+	tests := []struct {
+		name                  string
+		tileWidth, tileHeight float64 // of the tileset, the map grid is 16×16
+		view                  p5go.Rectangle
+		want                  []cell
+	}{
+		{"inside one cell", 16, 16, rect(20, 20, 10, 10), []cell{{1, 1}}},
+		{"across cells", 16, 16, rect(10, 10, 30, 10), []cell{{0, 0}, {1, 0}, {2, 0}, {0, 1}, {1, 1}, {2, 1}}},
+		{"outside the layer", 16, 16, rect(-100, -100, 50, 50), nil},
+		{"past the layer", 16, 16, rect(200, 20, 50, 10), nil},
+		// 32×48 tiles reach one cell right and two cells up from their own, so the cells left of
+		// and below the view are drawn too
+		{"oversized tiles", 32, 48, rect(20, 20, 10, 10), []cell{{0, 1}, {1, 1}, {0, 2}, {1, 2}, {0, 3}, {1, 3}}},
+		{"oversized tiles at the edge", 32, 48, rect(90, 60, 100, 100), []cell{{4, 3}, {5, 3}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := Parse([]byte(testMap(layer)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			ts := m.Tilesets[0]
+			ts.Image = "ground"
+			ts.TileWidth, ts.TileHeight = tt.tileWidth, tt.tileHeight
+			r := &recorder{}
+			m.Draw(r, tt.view)
+			var want []p5go.Vector
+			for _, c := range tt.want {
+				// tiles are drawn from the bottom-left corner of their cell
+				want = append(want, p5go.Vector{X: float64(c.col) * 16, Y: float64(c.row+1)*16 - tt.tileHeight})
+			}
+			if !slices.Equal(r.drawn, want) {
+				t.Errorf("drew tiles at %v, want %v", r.drawn, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+// Package turtle provides turtle graphics that record the drawn paths and optionally draw them
+// as they go.
+package turtle
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ryomak/p5go"
+)
+
+// State is the position, heading and pen of a turtle.
+type State struct {
+	Position p5go.Vector
+	Heading  float64 // radians, 0 points right and positive angles turn clockwise on screen
+	Pen      bool    // true while the pen is down
+}
+
+// Turtle moves around the plane and draws lines where it goes with its pen down.
+// Every line is recorded in Paths, so the drawing can be exported or drawn again later.
+type Turtle struct {
+	State
+	r     p5go.Renderer
+	stack []State
+	paths [][]p5go.Vector
+	open  bool // whether the last path is still being extended
+}
+
+// New creates a turtle at the origin heading right with its pen down.
+// If r is not nil every line is also drawn on it with Line as the turtle moves.
+func New(r p5go.Renderer) *Turtle {
+	return &Turtle{State: State{Pen: true}, r: r}
+}
+
+// Forward moves the turtle by distance along its heading.
+func (t *Turtle) Forward(distance float64) {
+	t.moveTo(t.Position.Add(p5go.FromAngle(t.Heading, distance)))
+}
+
+// Back moves the turtle by distance against its heading.
+func (t *Turtle) Back(distance float64) {
+	t.Forward(-distance)
+}
+
+// Turn turns the turtle by angle radians, clockwise on screen.
+func (t *Turtle) Turn(angle float64) {
+	t.Heading += angle
+}
+
+// Left turns the turtle counter-clockwise on screen by angle radians.
+func (t *Turtle) Left(angle float64) {
+	t.Turn(-angle)
+}
+
+// Right turns the turtle clockwise on screen by angle radians.
+func (t *Turtle) Right(angle float64) {
+	t.Turn(angle)
+}
+
+// SetHeading points the turtle at angle radians.
+func (t *Turtle) SetHeading(angle float64) {
+	t.Heading = angle
+}
+
+// Goto moves the turtle to (x, y), drawing a line if the pen is down.
+func (t *Turtle) Goto(x, y float64) {
+	t.moveTo(p5go.Vector{X: x, Y: y})
+}
+
+// PenUp lifts the pen, so the turtle moves without drawing.
+func (t *Turtle) PenUp() {
+	t.Pen = false
+	t.open = false
+}
+
+// PenDown lowers the pen, so the turtle draws where it moves.
+func (t *Turtle) PenDown() {
+	t.Pen = true
+}
+
+// PushState saves the position, heading and pen of the turtle.
+func (t *Turtle) PushState() {
+	t.stack = append(t.stack, t.State)
+}
+
+// PopState restores the state saved by the last PushState. The turtle jumps back without drawing.
+func (t *Turtle) PopState() {
+	if len(t.stack) == 0 {
+		return
+	}
+	t.State = t.stack[len(t.stack)-1]
+	t.stack = t.stack[:len(t.stack)-1]
+	t.open = false
+}
+
+// Paths returns the polylines drawn so far. A new path starts whenever the turtle moves with its
+// pen up or jumps with PopState.
+func (t *Turtle) Paths() [][]p5go.Vector {
+	return t.paths
+}
+
+// Bounds returns the bounding box of the drawn paths.
+func (t *Turtle) Bounds() p5go.Rectangle {
+	var pts []p5go.Vector
+	for _, p := range t.paths {
+		pts = append(pts, p...)
+	}
+	return p5go.BoundingBox(pts)
+}
+
+// Clear forgets the drawn paths, keeping the state of the turtle.
+func (t *Turtle) Clear() {
+	t.paths = nil
+	t.open = false
+}
+
+// Draw draws the recorded paths, each as one shape with Vertex.
+func (t *Turtle) Draw(r p5go.Renderer) {
+	for _, p := range t.paths {
+		p5go.DrawPolyline(r, p)
+	}
+}
+
+// SVGPath returns the recorded paths as SVG path data.
+func (t *Turtle) SVGPath() string {
+	var b strings.Builder
+	for _, path := range t.paths {
+		for i, p := range path {
+			cmd := "L"
+			if i == 0 {
+				cmd = "M"
+			}
+			fmt.Fprintf(&b, "%s%g %g ", cmd, p.X, p.Y)
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+func (t *Turtle) moveTo(p p5go.Vector) {
+	from := t.Position
+	t.Position = p
+	if !t.Pen {
+		return
+	}
+	if t.r != nil {
+		t.r.Line(from.X, from.Y, p.X, p.Y)
+	}
+	if !t.open {
+		t.paths = append(t.paths, []p5go.Vector{from})
+		t.open = true
+	}
+	last := len(t.paths) - 1
+	t.paths[last] = append(t.paths[last], p)
+}
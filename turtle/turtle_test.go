@@ -0,0 +1,91 @@
+package turtle
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ryomak/p5go"
+)
+
+func near(a, b p5go.Vector) bool {
+	return math.Abs(a.X-b.X) < 1e-9 && math.Abs(a.Y-b.Y) < 1e-9
+}
+
+func TestPaths(t *testing.T) {
+	tu := New(nil)
+	// a square clockwise on screen, then a jump and a separate line
+	for i := 0; i < 4; i++ {
+		tu.Forward(10)
+		tu.Right(math.Pi / 2)
+	}
+	tu.PenUp()
+	tu.Goto(20, 0)
+	tu.PenDown()
+	tu.Forward(5)
+
+	paths := tu.Paths()
+	if len(paths) != 2 {
+		t.Fatalf("%d paths, want 2", len(paths))
+	}
+	square := []p5go.Vector{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}, {X: 0, Y: 0}}
+	if len(paths[0]) != len(square) {
+		t.Fatalf("square path %v, want %v", paths[0], square)
+	}
+	for i := range square {
+		if !near(paths[0][i], square[i]) {
+			t.Errorf("square point %d = %v, want %v", i, paths[0][i], square[i])
+		}
+	}
+	if len(paths[1]) != 2 || !near(paths[1][0], p5go.Vector{X: 20}) || !near(paths[1][1], p5go.Vector{X: 25}) {
+		t.Errorf("line path = %v, want (20, 0) to (25, 0)", paths[1])
+	}
+	if got, want := tu.Bounds(), (p5go.Rectangle{Size: p5go.Vector{X: 25, Y: 10}}); !near(got.Position, want.Position) || !near(got.Size, want.Size) {
+		t.Errorf("Bounds = %v, want %v", got, want)
+	}
+}
+
+func TestPushPopState(t *testing.T) {
+	tu := New(nil)
+	tu.Forward(10)
+	tu.PushState()
+	tu.Left(math.Pi / 2)
+	tu.Forward(10)
+	tu.PopState()
+	tu.Forward(10)
+	// popping an empty stack does nothing
+	tu.PopState()
+
+	if !near(tu.Position, p5go.Vector{X: 20}) || tu.Heading != 0 {
+		t.Errorf("state after pop = %+v, want at (20, 0) heading 0", tu.State)
+	}
+	paths := tu.Paths()
+	if len(paths) != 2 || len(paths[0]) != 3 || len(paths[1]) != 2 {
+		t.Fatalf("paths = %v, want a branch and a separate continuation", paths)
+	}
+	if !near(paths[1][0], p5go.Vector{X: 10}) {
+		t.Errorf("path after pop starts at %v, want (10, 0)", paths[1][0])
+	}
+}
+
+func TestClearAndSVG(t *testing.T) {
+	tu := New(nil)
+	if b := tu.Bounds(); b != (p5go.Rectangle{}) {
+		t.Errorf("Bounds without paths = %v, want empty", b)
+	}
+	tu.Goto(3, 4)
+	tu.PenUp()
+	tu.Goto(0, 0)
+	tu.PenDown()
+	tu.Goto(1, 0)
+	if got, want := tu.SVGPath(), "M0 0 L3 4 M0 0 L1 0"; got != want {
+		t.Errorf("SVGPath = %q, want %q", got, want)
+	}
+	tu.Clear()
+	if len(tu.Paths()) != 0 || tu.SVGPath() != "" {
+		t.Errorf("paths left after Clear")
+	}
+	tu.Goto(2, 0)
+	if paths := tu.Paths(); len(paths) != 1 || !near(paths[0][0], p5go.Vector{X: 1}) {
+		t.Errorf("path after Clear = %v, want it to start at the turtle", paths)
+	}
+}
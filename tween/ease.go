@@ -0,0 +1,216 @@
+// Package tween animates values over time with easing functions, tweens and timelines.
+package tween
+
+import (
+	"math"
+)
+
+// Easing maps the progress of an animation, from 0 to 1, to the eased progress.
+// The functions of this package are the easing set of Robert Penner: In functions start slowly,
+// Out functions end slowly and InOut functions do both.
+type Easing func(t float64) float64
+
+// Linear keeps a constant speed.
+func Linear(t float64) float64 {
+	return t
+}
+
+// InQuad starts slowly with a quadratic curve.
+func InQuad(t float64) float64 {
+	return t * t
+}
+
+// OutQuad ends slowly with a quadratic curve.
+func OutQuad(t float64) float64 {
+	return out(InQuad, t)
+}
+
+// InOutQuad starts and ends slowly with a quadratic curve.
+func InOutQuad(t float64) float64 {
+	return inOut(InQuad, t)
+}
+
+// InCubic starts slowly with a cubic curve.
+func InCubic(t float64) float64 {
+	return t * t * t
+}
+
+// OutCubic ends slowly with a cubic curve.
+func OutCubic(t float64) float64 {
+	return out(InCubic, t)
+}
+
+// InOutCubic starts and ends slowly with a cubic curve.
+func InOutCubic(t float64) float64 {
+	return inOut(InCubic, t)
+}
+
+// InQuart starts slowly with a quartic curve.
+func InQuart(t float64) float64 {
+	return t * t * t * t
+}
+
+// OutQuart ends slowly with a quartic curve.
+func OutQuart(t float64) float64 {
+	return out(InQuart, t)
+}
+
+// InOutQuart starts and ends slowly with a quartic curve.
+func InOutQuart(t float64) float64 {
+	return inOut(InQuart, t)
+}
+
+// InQuint starts slowly with a quintic curve.
+func InQuint(t float64) float64 {
+	return t * t * t * t * t
+}
+
+// OutQuint ends slowly with a quintic curve.
+func OutQuint(t float64) float64 {
+	return out(InQuint, t)
+}
+
+// InOutQuint starts and ends slowly with a quintic curve.
+func InOutQuint(t float64) float64 {
+	return inOut(InQuint, t)
+}
+
+// InSine starts slowly with a sinusoidal curve.
+func InSine(t float64) float64 {
+	return 1 - math.Cos(t*math.Pi/2)
+}
+
+// OutSine ends slowly with a sinusoidal curve.
+func OutSine(t float64) float64 {
+	return out(InSine, t)
+}
+
+// InOutSine starts and ends slowly with a sinusoidal curve.
+func InOutSine(t float64) float64 {
+	return inOut(InSine, t)
+}
+
+// InExpo starts slowly with an exponential curve.
+func InExpo(t float64) float64 {
+	if t <= 0 {
+		return 0
+	}
+	return math.Pow(2, 10*t-10)
+}
+
+// OutExpo ends slowly with an exponential curve.
+func OutExpo(t float64) float64 {
+	return out(InExpo, t)
+}
+
+// InOutExpo starts and ends slowly with an exponential curve.
+func InOutExpo(t float64) float64 {
+	return inOut(InExpo, t)
+}
+
+// InCirc starts slowly with a circular curve.
+func InCirc(t float64) float64 {
+	return 1 - math.Sqrt(1-t*t)
+}
+
+// OutCirc ends slowly with a circular curve.
+func OutCirc(t float64) float64 {
+	return out(InCirc, t)
+}
+
+// InOutCirc starts and ends slowly with a circular curve.
+func InOutCirc(t float64) float64 {
+	return inOut(InCirc, t)
+}
+
+// backOvershoot is Penner's overshoot of the back curves, about 10%.
+const backOvershoot = 1.70158
+
+// InBack starts slowly with an overshooting curve.
+func InBack(t float64) float64 {
+	const s = backOvershoot
+	return t * t * ((s+1)*t - s)
+}
+
+// OutBack ends slowly with an overshooting curve.
+func OutBack(t float64) float64 {
+	return out(InBack, t)
+}
+
+// InOutBack starts and ends slowly with an overshooting curve, scaling the overshoot by 1.525 as
+// Penner does.
+func InOutBack(t float64) float64 {
+	const s = backOvershoot * 1.525
+	if t < 0.5 {
+		t *= 2
+		return t * t * ((s+1)*t - s) / 2
+	}
+	t = 2*t - 2
+	return (t*t*((s+1)*t+s) + 2) / 2
+}
+
+// InElastic starts slowly with an elastic curve.
+func InElastic(t float64) float64 {
+	if t <= 0 || t >= 1 {
+		return t
+	}
+	return -math.Pow(2, 10*t-10) * math.Sin((t*10-10.75)*(2*math.Pi)/3)
+}
+
+// OutElastic ends slowly with an elastic curve.
+func OutElastic(t float64) float64 {
+	return out(InElastic, t)
+}
+
+// InOutElastic starts and ends slowly with an elastic curve, with Penner's longer period.
+func InOutElastic(t float64) float64 {
+	const c = 2 * math.Pi / 4.5
+	switch {
+	case t <= 0 || t >= 1:
+		return t
+	case t < 0.5:
+		return -math.Pow(2, 20*t-10) * math.Sin((20*t-11.125)*c) / 2
+	}
+	return math.Pow(2, -20*t+10)*math.Sin((20*t-11.125)*c)/2 + 1
+}
+
+// InBounce starts with bounces.
+func InBounce(t float64) float64 {
+	return out(OutBounce, t)
+}
+
+// OutBounce ends bouncing like a dropped ball.
+func OutBounce(t float64) float64 {
+	const n, d = 7.5625, 2.75
+	switch {
+	case t < 1/d:
+		return n * t * t
+	case t < 2/d:
+		t -= 1.5 / d
+		return n*t*t + 0.75
+	case t < 2.5/d:
+		t -= 2.25 / d
+		return n*t*t + 0.9375
+	default:
+		t -= 2.625 / d
+		return n*t*t + 0.984375
+	}
+}
+
+// InOutBounce bounces at the start and at the end.
+func InOutBounce(t float64) float64 {
+	return inOut(InBounce, t)
+}
+
+// out mirrors an In easing into its Out version.
+func out(in Easing, t float64) float64 {
+	return 1 - in(1-t)
+}
+
+// inOut joins an In easing and its Out version at the middle.
+func inOut(in Easing, t float64) float64 {
+	if t < 0.5 {
+		return in(2*t) / 2
+	}
+	return 1 - in(2-2*t)/2
+}
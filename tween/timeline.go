@@ -0,0 +1,114 @@
+package tween
+
+import (
+	"math"
+	"sort"
+)
+
+// Timeline plays animations at given start times. They may overlap, and a timeline can be added
+// to another one. Seek gives the exact state at any time, so rendering frame n at
+// Seek(float64(n) / fps) gives the same image in a live sketch and in an offline render.
+type Timeline struct {
+	entries []entry
+	time    float64
+}
+
+type entry struct {
+	anim  Animation
+	start float64
+}
+
+var _ Animation = (*Timeline)(nil)
+
+// NewTimeline creates an empty timeline.
+func NewTimeline() *Timeline {
+	return &Timeline{}
+}
+
+// Add plays a starting at the given time, in seconds from the start of the timeline.
+func (tl *Timeline) Add(a Animation, start float64) *Timeline {
+	tl.entries = append(tl.entries, entry{anim: a, start: start})
+	// stable so animations starting together are applied in the order they were added
+	sort.SliceStable(tl.entries, func(i, j int) bool { return tl.entries[i].start < tl.entries[j].start })
+	return tl
+}
+
+// Append plays a gap seconds after the end of the animations added so far.
+func (tl *Timeline) Append(a Animation, gap float64) *Timeline {
+	end := tl.End()
+	if math.IsInf(end, 1) {
+		end = 0
+	}
+	return tl.Add(a, end+gap)
+}
+
+// End returns the time the last animation finishes.
+func (tl *Timeline) End() float64 {
+	var end float64
+	for _, e := range tl.entries {
+		end = math.Max(end, e.start+e.anim.End())
+	}
+	return end
+}
+
+// Time returns the current time of the timeline, in seconds.
+func (tl *Timeline) Time() float64 {
+	return tl.time
+}
+
+// Done returns true once every animation has finished.
+func (tl *Timeline) Done() bool {
+	return tl.time >= tl.End()
+}
+
+// Update advances the timeline by dt seconds.
+func (tl *Timeline) Update(dt float64) {
+	tl.Seek(tl.time + dt)
+}
+
+// Seek moves every animation to the given time of the timeline.
+// Animations that have not started yet are applied first, in reverse order, and the others in the
+// order of their start, so when several animate the same target the latest one started wins.
+// The tweens and timelines that have not started yet take their start value without calling
+// their callbacks.
+func (tl *Timeline) Seek(time float64) {
+	tl.seek(time, false)
+}
+
+func (tl *Timeline) seekQuiet(time float64) {
+	tl.seek(time, true)
+}
+
+func (tl *Timeline) seek(time float64, quiet bool) {
+	tl.time = time
+	for i := len(tl.entries) - 1; i >= 0; i-- {
+		if e := tl.entries[i]; time < e.start {
+			seekQuiet(e.anim, time-e.start)
+		}
+	}
+	for _, e := range tl.entries {
+		if time < e.start {
+			continue
+		}
+		if quiet {
+			seekQuiet(e.anim, time-e.start)
+		} else {
+			e.anim.Seek(time - e.start)
+		}
+	}
+}
+
+// quietSeeker is implemented by the animations of this package, which can seek without calling
+// their callbacks.
+type quietSeeker interface {
+	seekQuiet(time float64)
+}
+
+// seekQuiet seeks a without calling its callbacks if it supports that, and with Seek otherwise.
+func seekQuiet(a Animation, time float64) {
+	if q, ok := a.(quietSeeker); ok {
+		q.seekQuiet(time)
+		return
+	}
+	a.Seek(time)
+}
@@ -0,0 +1,190 @@
+package tween
+
+import (
+	"math"
+
+	"github.com/ryomak/p5go"
+)
+
+// Value is a type a Tween can interpolate.
+type Value interface {
+	float64 | p5go.Vector | p5go.Color
+}
+
+// Animation is anything a Timeline can sequence. Its state only depends on the time it was last
+// seeked to, so seeking to the same time always gives the same result.
+type Animation interface {
+	// End returns the time the animation finishes, +Inf if it repeats forever.
+	End() float64
+	// Seek moves the animation to time seconds from its start.
+	Seek(time float64)
+}
+
+// Tween animates a value from From to To. Its time starts at 0 when it is created; Update advances
+// it and Seek jumps to any time.
+type Tween[T Value] struct {
+	From, To T
+	Duration float64 // seconds of one play
+	Delay    float64 // seconds before the first play
+	Repeat   int     // number of extra plays, -1 to repeat forever
+	Yoyo     bool    // play every other repetition backwards
+	Ease     Easing  // Linear if nil
+
+	// Target, if set, receives the value whenever it changes.
+	Target *T
+
+	// Callbacks are called when the time moves forward past the start, a repetition or the end.
+	OnStart    func()
+	OnUpdate   func(v T)
+	OnRepeat   func(repetition int)
+	OnComplete func()
+
+	time  float64
+	value T
+}
+
+var _ Animation = (*Tween[float64])(nil)
+
+// New creates a tween from from to to over duration seconds with the easing ease.
+func New[T Value](from, to T, duration float64, ease Easing) *Tween[T] {
+	return &Tween[T]{From: from, To: to, Duration: duration, Ease: ease, value: from}
+}
+
+// Value returns the current value.
+func (t *Tween[T]) Value() T {
+	return t.value
+}
+
+// Time returns the current time of the tween, in seconds.
+func (t *Tween[T]) Time() float64 {
+	return t.time
+}
+
+// Done returns true once the tween has finished.
+func (t *Tween[T]) Done() bool {
+	return t.time >= t.End()
+}
+
+// End returns the time the tween finishes, including the delay and the repetitions.
+func (t *Tween[T]) End() float64 {
+	if t.Repeat < 0 {
+		return math.Inf(1)
+	}
+	return t.Delay + t.Duration*float64(t.Repeat+1)
+}
+
+// Update advances the tween by dt seconds.
+func (t *Tween[T]) Update(dt float64) {
+	t.Seek(t.time + dt)
+}
+
+// Reset moves the tween back to its start without calling the callbacks.
+func (t *Tween[T]) Reset() {
+	t.seekQuiet(0)
+}
+
+// seekQuiet moves the tween to time and updates its value without calling the callbacks.
+func (t *Tween[T]) seekQuiet(time float64) {
+	t.time = time
+	t.set(t.At(time))
+}
+
+// Seek moves the tween to time seconds from its start and updates its value.
+func (t *Tween[T]) Seek(time float64) {
+	prev := t.time
+	t.time = time
+	if time > prev {
+		t.fire(prev, time)
+	}
+	t.set(t.At(time))
+	if t.OnUpdate != nil {
+		t.OnUpdate(t.value)
+	}
+	if time > prev && prev < t.End() && time >= t.End() && t.OnComplete != nil {
+		t.OnComplete()
+	}
+}
+
+// At returns the value of the tween at time seconds from its start, without changing it.
+func (t *Tween[T]) At(time float64) T {
+	return lerp(t.From, t.To, t.progress(time))
+}
+
+// progress returns the eased progress at time.
+func (t *Tween[T]) progress(time float64) float64 {
+	local := time - t.Delay
+	if local <= 0 {
+		return t.ease(0)
+	}
+	if time >= t.End() {
+		local = t.End() - t.Delay
+	}
+	if t.Duration <= 0 {
+		return t.ease(t.direction(t.Repeat, 1))
+	}
+	n := math.Floor(local / t.Duration)
+	f := local/t.Duration - n
+	if f == 0 && n > 0 {
+		// the end of a play belongs to that play, not to the start of the next one
+		n, f = n-1, 1
+	}
+	return t.ease(t.direction(int(n), f))
+}
+
+// direction flips the progress f of play n for yoyo tweens.
+func (t *Tween[T]) direction(n int, f float64) float64 {
+	if t.Yoyo && n%2 == 1 {
+		return 1 - f
+	}
+	return f
+}
+
+func (t *Tween[T]) ease(f float64) float64 {
+	if t.Ease == nil {
+		return f
+	}
+	return t.Ease(f)
+}
+
+// fire calls OnStart and OnRepeat for the events between the times from and to.
+func (t *Tween[T]) fire(from, to float64) {
+	if t.OnStart != nil && from < t.Delay && to >= t.Delay {
+		t.OnStart()
+	}
+	if t.OnRepeat == nil || t.Duration <= 0 {
+		return
+	}
+	end := math.Min(to, t.End())
+	for n := math.Floor((from-t.Delay)/t.Duration) + 1; t.Delay+n*t.Duration <= end; n++ {
+		if n >= 1 && t.Delay+n*t.Duration < t.End() {
+			t.OnRepeat(int(n))
+		}
+	}
+}
+
+func (t *Tween[T]) set(v T) {
+	t.value = v
+	if t.Target != nil {
+		*t.Target = v
+	}
+}
+
+// lerp interpolates between the values of any Value type.
+func lerp[T Value](a, b T, f float64) T {
+	switch a := any(a).(type) {
+	case float64:
+		b := any(b).(float64)
+		return any(a + (b-a)*f).(T)
+	case p5go.Vector:
+		return any(a.Lerp(any(b).(p5go.Vector), f)).(T)
+	case p5go.Color:
+		b := any(b).(p5go.Color)
+		return any(p5go.Color{
+			R: a.R + (b.R-a.R)*f,
+			G: a.G + (b.G-a.G)*f,
+			B: a.B + (b.B-a.B)*f,
+			A: a.A + (b.A-a.A)*f,
+		}).(T)
+	}
+	return a
+}
@@ -0,0 +1,166 @@
+package tween
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+)
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestEasingEnds(t *testing.T) {
+	easings := map[string]Easing{
+		"Linear": Linear, "InQuad": InQuad, "OutQuad": OutQuad, "InOutQuad": InOutQuad,
+		"InCubic": InCubic, "OutCubic": OutCubic, "InOutCubic": InOutCubic,
+		"InQuart": InQuart, "OutQuart": OutQuart, "InOutQuart": InOutQuart,
+		"InQuint": InQuint, "OutQuint": OutQuint, "InOutQuint": InOutQuint,
+		"InSine": InSine, "OutSine": OutSine, "InOutSine": InOutSine,
+		"InExpo": InExpo, "OutExpo": OutExpo, "InOutExpo": InOutExpo,
+		"InCirc": InCirc, "OutCirc": OutCirc, "InOutCirc": InOutCirc,
+		"InBack": InBack, "OutBack": OutBack, "InOutBack": InOutBack,
+		"InElastic": InElastic, "OutElastic": OutElastic, "InOutElastic": InOutElastic,
+		"InBounce": InBounce, "OutBounce": OutBounce, "InOutBounce": InOutBounce,
+	}
+	for name, e := range easings {
+		if !near(e(0), 0) || !near(e(1), 1) {
+			t.Errorf("%s(0), %s(1) = %v, %v, want 0, 1", name, name, e(0), e(1))
+		}
+	}
+}
+
+func TestPennerInOut(t *testing.T) {
+	// values of Penner's easeInOutBack and easeInOutElastic
+	tests := []struct {
+		name string
+		ease Easing
+		t    float64
+		want float64
+	}{
+		{"InOutBack", InOutBack, 0.2, -0.0925557},
+		{"InOutBack", InOutBack, 0.5, 0.5},
+		{"InOutBack", InOutBack, 0.8, 1.0925557},
+		{"InOutElastic", InOutElastic, 0.3, 0.0239389},
+		{"InOutElastic", InOutElastic, 0.5, 0.5},
+		{"InOutElastic", InOutElastic, 0.7, 0.9760611},
+	}
+	for _, tt := range tests {
+		if got := tt.ease(tt.t); math.Abs(got-tt.want) > 1e-6 {
+			t.Errorf("%s(%v) = %.7f, want %.7f", tt.name, tt.t, got, tt.want)
+		}
+	}
+	// both are symmetric around the middle
+	for x := 0.0; x <= 1; x += 0.05 {
+		for name, e := range map[string]Easing{"InOutBack": InOutBack, "InOutElastic": InOutElastic} {
+			if got := e(x) + e(1-x); !near(got, 1) {
+				t.Errorf("%s(%v) + %s(%v) = %v, want 1", name, x, name, 1-x, got)
+			}
+		}
+	}
+}
+
+func TestRepeatYoyoEdges(t *testing.T) {
+	tests := []struct {
+		name   string
+		repeat int
+		yoyo   bool
+		times  []float64
+		values []float64
+	}{
+		{"once", 0, false, []float64{-1, 0.5, 1, 1.5, 2, 3}, []float64{0, 0, 0, 5, 10, 10}},
+		{"repeat", 2, false, []float64{1, 2, 2.25, 3, 3.5, 4, 5}, []float64{0, 10, 2.5, 10, 5, 10, 10}},
+		{"yoyo", 2, true, []float64{1, 2, 2.25, 3, 3.5, 4, 5}, []float64{0, 10, 7.5, 0, 5, 10, 10}},
+		{"yoyo even plays", 1, true, []float64{2, 2.5, 3, 4}, []float64{10, 5, 0, 0}},
+		{"forever", -1, true, []float64{100, 100.5, 101}, []float64{10, 5, 0}},
+	}
+	for _, tt := range tests {
+		tw := New(0.0, 10, 1, nil)
+		tw.Delay, tw.Repeat, tw.Yoyo = 1, tt.repeat, tt.yoyo
+		for i, time := range tt.times {
+			if got := tw.At(time); !near(got, tt.values[i]) {
+				t.Errorf("%s: At(%v) = %v, want %v", tt.name, time, got, tt.values[i])
+			}
+		}
+	}
+}
+
+func TestSeekIsExact(t *testing.T) {
+	build := func() (*Timeline, *float64, *float64) {
+		var a, b float64
+		x := New(0.0, 100, 0.7, InOutBack)
+		x.Target, x.Repeat, x.Yoyo = &a, 2, true
+		y := New(-5.0, 5, 0.3, OutBounce)
+		y.Target = &b
+		tl := NewTimeline().Add(x, 0.25).Append(y, 0.1)
+		return tl, &a, &b
+	}
+	// stepping frame by frame ends in the same state as seeking straight to a frame, in any order
+	stepped, sa, sb := build()
+	sought, ta, tb := build()
+	const fps = 60
+	for n := 1; n <= 180; n++ {
+		stepped.Update(1.0 / fps)
+		sought.Seek(2)
+		sought.Seek(float64(n) / fps)
+		if !near(*sa, *ta) || !near(*sb, *tb) {
+			t.Fatalf("frame %d: stepped %v, %v, sought %v, %v", n, *sa, *sb, *ta, *tb)
+		}
+	}
+	if !stepped.Done() || !near(stepped.End(), 0.25+2.1+0.1+0.3) {
+		t.Errorf("timeline ends at %v, done %v", stepped.End(), stepped.Done())
+	}
+	// the later tween holds its start value until it starts
+	sought.Seek(0)
+	if *ta != 0 || *tb != -5 {
+		t.Errorf("values before start = %v, %v, want 0, -5", *ta, *tb)
+	}
+}
+
+func TestCallbackOrder(t *testing.T) {
+	var log []string
+	tw := New(0.0, 10, 1, nil)
+	tw.Repeat = 1
+	tw.OnStart = func() { log = append(log, "start") }
+	tw.OnUpdate = func(v float64) { log = append(log, fmt.Sprint("update ", v)) }
+	tw.OnRepeat = func(n int) { log = append(log, fmt.Sprint("repeat ", n)) }
+	tw.OnComplete = func() { log = append(log, "complete") }
+	tl := NewTimeline().Add(tw, 1)
+
+	steps := []struct {
+		time float64
+		want []string
+	}{
+		// nothing is called before the tween starts
+		{0.5, nil},
+		{0.9, nil},
+		{1.5, []string{"start", "update 5"}},
+		{2.5, []string{"repeat 1", "update 5"}},
+		{3, []string{"update 10", "complete"}},
+		{4, []string{"update 10"}},
+		// seeking back before the start is quiet too, and playing again starts again
+		{0, nil},
+		{3.5, []string{"start", "repeat 1", "update 10", "complete"}},
+	}
+	for _, s := range steps {
+		log = nil
+		tl.Seek(s.time)
+		if !slices.Equal(log, s.want) {
+			t.Errorf("Seek(%v) called %v, want %v", s.time, log, s.want)
+		}
+	}
+
+	// the same holds for a tween in a nested timeline
+	log = nil
+	outer := NewTimeline().Add(NewTimeline().Add(tw, 1), 1)
+	outer.Seek(0)
+	outer.Seek(1.5)
+	if len(log) != 0 {
+		t.Errorf("nested tween before its start called %v", log)
+	}
+	outer.Seek(2.5)
+	if want := []string{"start", "update 5"}; !slices.Equal(log, want) {
+		t.Errorf("nested tween called %v, want %v", log, want)
+	}
+}
@@ -0,0 +1,33 @@
+package p5go
+
+// Color represents a color with RGBA components
+type Color struct {
+	R, G, B, A float64
+}
+
+// Vector represents a 2D vector
+type Vector struct {
+	X, Y float64
+}
+
+// Rectangle represents a rectangle with position and size
+type Rectangle struct {
+	Position Vector
+	Size     Vector
+}
+
+// Circle represents a circle with center position and diameter
+type Circle struct {
+	Position Vector
+	Diameter float64
+}
+
+// Line represents a line with start and end points
+type Line struct {
+	Start, End Vector
+}
+
+// Triangle represents a triangle with three vertices
+type Triangle struct {
+	V1, V2, V3 Vector
+}
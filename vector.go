@@ -0,0 +1,94 @@
+package p5go
+
+import (
+	"math"
+)
+
+// Add returns v + o.
+func (v Vector) Add(o Vector) Vector {
+	return Vector{X: v.X + o.X, Y: v.Y + o.Y}
+}
+
+// Sub returns v - o.
+func (v Vector) Sub(o Vector) Vector {
+	return Vector{X: v.X - o.X, Y: v.Y - o.Y}
+}
+
+// Mult returns v scaled by n.
+func (v Vector) Mult(n float64) Vector {
+	return Vector{X: v.X * n, Y: v.Y * n}
+}
+
+// Div returns v divided by n.
+func (v Vector) Div(n float64) Vector {
+	return Vector{X: v.X / n, Y: v.Y / n}
+}
+
+// Dot returns the dot product of v and o.
+func (v Vector) Dot(o Vector) float64 {
+	return v.X*o.X + v.Y*o.Y
+}
+
+// Cross returns the z component of the cross product of v and o.
+func (v Vector) Cross(o Vector) float64 {
+	return v.X*o.Y - v.Y*o.X
+}
+
+// Mag returns the length of v.
+func (v Vector) Mag() float64 {
+	return math.Hypot(v.X, v.Y)
+}
+
+// MagSq returns the squared length of v.
+func (v Vector) MagSq() float64 {
+	return v.X*v.X + v.Y*v.Y
+}
+
+// Dist returns the distance between v and o.
+func (v Vector) Dist(o Vector) float64 {
+	return math.Hypot(o.X-v.X, o.Y-v.Y)
+}
+
+// Normalize returns v scaled to length 1, or the zero vector if v is zero.
+func (v Vector) Normalize() Vector {
+	m := v.Mag()
+	if m == 0 {
+		return Vector{}
+	}
+	return v.Div(m)
+}
+
+// SetMag returns v scaled to length n.
+func (v Vector) SetMag(n float64) Vector {
+	return v.Normalize().Mult(n)
+}
+
+// Limit returns v with its length limited to max.
+func (v Vector) Limit(max float64) Vector {
+	if v.MagSq() > max*max {
+		return v.SetMag(max)
+	}
+	return v
+}
+
+// Heading returns the angle of v in radians.
+func (v Vector) Heading() float64 {
+	return math.Atan2(v.Y, v.X)
+}
+
+// Rotate returns v rotated by angle radians.
+func (v Vector) Rotate(angle float64) Vector {
+	s, c := math.Sincos(angle)
+	return Vector{X: v.X*c - v.Y*s, Y: v.X*s + v.Y*c}
+}
+
+// Lerp returns the linear interpolation between v and o by amt.
+func (v Vector) Lerp(o Vector, amt float64) Vector {
+	return Vector{X: v.X + (o.X-v.X)*amt, Y: v.Y + (o.Y-v.Y)*amt}
+}
+
+// FromAngle returns a vector of the given length pointing in the direction of angle radians.
+func FromAngle(angle, length float64) Vector {
+	s, c := math.Sincos(angle)
+	return Vector{X: c * length, Y: s * length}
+}
@@ -0,0 +1,50 @@
+//go:build js && wasm
+
+package verlet
+
+import (
+	"github.com/ryomak/p5go"
+)
+
+// mouseID is the grab id of the mouse in MouseDrag. Pointer IDs are never negative.
+const mouseID = -1
+
+// MouseDrag lets the mouse drag the points of w within radius of where it is pressed. It sets the
+// MousePressed, MouseDragged and MouseReleased handlers, replacing those of the sketch; use
+// PointerDrag to drag with several fingers or to keep the mouse handlers.
+func MouseDrag(w *World, radius float64) p5go.Func {
+	return func(c *p5go.Canvas) {
+		p5go.MousePressed(func(c *p5go.Canvas, e p5go.MouseEvent) {
+			w.Grab(mouseID, p5go.Vector{X: e.X, Y: e.Y}, radius)
+		})(c)
+		p5go.MouseDragged(func(c *p5go.Canvas, e p5go.MouseDraggedEvent) {
+			w.DragTo(mouseID, p5go.Vector{X: e.X, Y: e.Y})
+		})(c)
+		p5go.MouseReleased(func(c *p5go.Canvas, e p5go.MouseReleasedEvent) {
+			w.Release(mouseID)
+		})(c)
+	}
+}
+
+// PointerDrag lets the mouse, touches and pens drag the points of w within radius of where they
+// press. It registers PointerDown, PointerMove, PointerUp and PointerCancel rather than the mouse
+// handlers: browsers send pointer events for the mouse as well, so mouse drags work the same, but
+// every finger has its own pointer ID and drags its own point, which MousePressed and
+// MouseDragged cannot tell apart. The MousePressed, MouseDragged and MouseReleased handlers of the
+// sketch keep working alongside it, and so do other pointer handlers, which are added, not replaced.
+func PointerDrag(w *World, radius float64) p5go.Func {
+	return func(c *p5go.Canvas) {
+		pos := func(e p5go.PointerEvent) p5go.Vector { return p5go.Vector{X: e.X, Y: e.Y} }
+		p5go.PointerDown(func(c *p5go.Canvas, e p5go.PointerEvent) {
+			w.Grab(e.ID, pos(e), radius)
+		})(c)
+		p5go.PointerMove(func(c *p5go.Canvas, e p5go.PointerEvent) {
+			w.DragTo(e.ID, pos(e))
+		})(c)
+		release := func(c *p5go.Canvas, e p5go.PointerEvent) {
+			w.Release(e.ID)
+		}
+		p5go.PointerUp(release)(c)
+		p5go.PointerCancel(release)(c)
+	}
+}
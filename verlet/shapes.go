@@ -0,0 +1,58 @@
+package verlet
+
+import (
+	"github.com/ryomak/p5go"
+)
+
+// Rope adds a chain of segments+1 points from one position to another, connected by distance constraints.
+func (w *World) Rope(from, to p5go.Vector, segments int, stiffness float64) []*Point {
+	segments = max(segments, 1)
+	pts := make([]*Point, segments+1)
+	for i := range pts {
+		p := from.Lerp(to, float64(i)/float64(segments))
+		pts[i] = w.AddPoint(p.X, p.Y)
+		if i > 0 {
+			w.Connect(pts[i-1], pts[i], stiffness)
+		}
+	}
+	return pts
+}
+
+// Cloth adds a grid of cols x rows points covering bounds, connected to their horizontal and
+// vertical neighbours. The points are indexed [row][col]; pin some of the top row to hang it.
+func (w *World) Cloth(bounds p5go.Rectangle, cols, rows int, stiffness float64) [][]*Point {
+	cols, rows = max(cols, 2), max(rows, 2)
+	grid := make([][]*Point, rows)
+	for j := range grid {
+		grid[j] = make([]*Point, cols)
+		for i := range grid[j] {
+			grid[j][i] = w.AddPoint(
+				bounds.Position.X+bounds.Size.X*float64(i)/float64(cols-1),
+				bounds.Position.Y+bounds.Size.Y*float64(j)/float64(rows-1),
+			)
+			if i > 0 {
+				w.Connect(grid[j][i-1], grid[j][i], stiffness)
+			}
+			if j > 0 {
+				w.Connect(grid[j-1][i], grid[j][i], stiffness)
+			}
+		}
+	}
+	return grid
+}
+
+// SoftBody adds a ring of points through outline, connected along the outline and each to the
+// opposite point so the shape keeps its volume.
+func (w *World) SoftBody(outline []p5go.Vector, stiffness float64) []*Point {
+	pts := make([]*Point, len(outline))
+	for i, p := range outline {
+		pts[i] = w.AddPoint(p.X, p.Y)
+	}
+	for i := range pts {
+		w.Connect(pts[i], pts[(i+1)%len(pts)], stiffness)
+		if j := i + len(pts)/2; j < len(pts) {
+			w.Connect(pts[i], pts[j], stiffness)
+		}
+	}
+	return pts
+}
@@ -0,0 +1,153 @@
+// Package verlet simulates points connected by constraints with Verlet integration, for ropes,
+// cloth and soft bodies.
+package verlet
+
+import (
+	"math"
+
+	"github.com/ryomak/p5go"
+)
+
+// Point is a simulated particle. Its velocity is the difference between Position and Previous.
+type Point struct {
+	Position p5go.Vector
+	Previous p5go.Vector
+	Mass     float64 // 1 if zero
+	Radius   float64 // used for collisions
+	Pinned   bool    // pinned points do not move
+
+	force p5go.Vector
+}
+
+// Velocity returns the displacement of the point during the last step.
+func (p *Point) Velocity() p5go.Vector {
+	return p.Position.Sub(p.Previous)
+}
+
+// SetVelocity sets the displacement of the point for the next step.
+func (p *Point) SetVelocity(v p5go.Vector) {
+	p.Previous = p.Position.Sub(v)
+}
+
+// MoveTo moves the point without giving it a velocity.
+func (p *Point) MoveTo(pos p5go.Vector) {
+	p.Position, p.Previous = pos, pos
+}
+
+// ApplyForce adds a force for the next step.
+func (p *Point) ApplyForce(f p5go.Vector) {
+	p.force = p.force.Add(f)
+}
+
+// invMass returns the inverse mass, 0 for pinned points.
+func (p *Point) invMass() float64 {
+	if p.Pinned {
+		return 0
+	}
+	if p.Mass <= 0 {
+		return 1
+	}
+	return 1 / p.Mass
+}
+
+// Constraint moves points to satisfy a condition. It is solved several times per step.
+type Constraint interface {
+	Solve()
+}
+
+// Distance keeps two points at a fixed distance, like a stick or a spring.
+type Distance struct {
+	A, B   *Point
+	Length float64
+	// Stiffness is the fraction of the error corrected at each iteration, from 0 for none to 1 for rigid.
+	Stiffness float64
+	// TearLength breaks the constraint when the points get further apart, 0 for never.
+	TearLength float64
+	Broken     bool
+}
+
+// Solve moves the points towards the rest length, in proportion to their inverse masses.
+func (d *Distance) Solve() {
+	if d.Broken {
+		return
+	}
+	delta := d.B.Position.Sub(d.A.Position)
+	dist := delta.Mag()
+	if d.TearLength > 0 && dist > d.TearLength {
+		d.Broken = true
+		return
+	}
+	wa, wb := d.A.invMass(), d.B.invMass()
+	if dist == 0 || wa+wb == 0 {
+		return
+	}
+	corr := delta.Mult((dist - d.Length) / dist * d.Stiffness / (wa + wb))
+	d.A.Position = d.A.Position.Add(corr.Mult(wa))
+	d.B.Position = d.B.Position.Sub(corr.Mult(wb))
+}
+
+// Angle keeps the angle at B between A and C, for stiff ropes and soft body shapes.
+type Angle struct {
+	A, B, C   *Point
+	Angle     float64 // rest angle from BA to BC, in radians
+	Stiffness float64
+}
+
+// Solve rotates A and C around B towards the rest angle.
+func (a *Angle) Solve() {
+	ba, bc := a.A.Position.Sub(a.B.Position), a.C.Position.Sub(a.B.Position)
+	cur := math.Atan2(ba.Cross(bc), ba.Dot(bc))
+	diff := math.Remainder(cur-a.Angle, p5go.TWO_PI) * a.Stiffness
+	wa, wc := a.A.invMass(), a.C.invMass()
+	if wa+wc == 0 {
+		return
+	}
+	a.A.Position = a.B.Position.Add(ba.Rotate(diff * wa / (wa + wc)))
+	a.C.Position = a.B.Position.Add(bc.Rotate(-diff * wc / (wa + wc)))
+}
+
+// Collider pushes points out of an obstacle.
+type Collider interface {
+	Collide(p *Point)
+}
+
+// CircleCollider is a solid circle.
+type CircleCollider p5go.Circle
+
+// Collide pushes p out of the circle.
+func (c CircleCollider) Collide(p *Point) {
+	r := p5go.Circle(c).Radius() + p.Radius
+	d := p.Position.Sub(c.Position)
+	if d.MagSq() >= r*r {
+		return
+	}
+	if d == (p5go.Vector{}) {
+		d = p5go.Vector{Y: -1}
+	}
+	p.Position = c.Position.Add(d.SetMag(r))
+}
+
+// RectCollider is a solid rectangle.
+type RectCollider p5go.Rectangle
+
+// Collide pushes p out of the rectangle through the nearest side.
+func (c RectCollider) Collide(p *Point) {
+	rect := p5go.Rectangle(c)
+	lo, hi := rect.Min(), rect.Max()
+	pos, r := p.Position, p.Radius
+	if pos.X <= lo.X-r || pos.X >= hi.X+r || pos.Y <= lo.Y-r || pos.Y >= hi.Y+r {
+		return
+	}
+	left, right := pos.X-(lo.X-r), hi.X+r-pos.X
+	top, bottom := pos.Y-(lo.Y-r), hi.Y+r-pos.Y
+	switch math.Min(math.Min(left, right), math.Min(top, bottom)) {
+	case left:
+		p.Position.X = lo.X - r
+	case right:
+		p.Position.X = hi.X + r
+	case top:
+		p.Position.Y = lo.Y - r
+	default:
+		p.Position.Y = hi.Y + r
+	}
+}
@@ -0,0 +1,238 @@
+package verlet
+
+import (
+	"math"
+
+	"github.com/ryomak/p5go"
+)
+
+// DefaultTimeStep is the fixed time step of a new world, in seconds.
+const DefaultTimeStep = 1.0 / 60
+
+// defaultMaxSteps is the MaxSteps of a new world.
+const defaultMaxSteps = 5
+
+// World holds the points, constraints and colliders of a simulation.
+type World struct {
+	Points      []*Point
+	Constraints []Constraint
+	Colliders   []Collider
+
+	Gravity p5go.Vector
+	// Damping scales the velocity at every step, 1 for none.
+	Damping float64
+	// Iterations is the number of times the constraints are solved per step.
+	Iterations int
+	// Bounds keeps the points inside the rectangle when it has a size.
+	Bounds p5go.Rectangle
+	// Bounce is the fraction of the velocity kept when a point hits Bounds.
+	Bounce float64
+	// TimeStep is the fixed duration of a step and MaxSteps limits the steps per Update, so a slow
+	// frame does not make the next one slower. Update sets them to DefaultTimeStep and 5 when they
+	// are not positive.
+	TimeStep float64
+	MaxSteps int
+
+	pending float64
+	grabs   map[int]*grab
+}
+
+// grab is a point held by a pointer.
+type grab struct {
+	point  *Point
+	target p5go.Vector
+	pinned bool
+}
+
+// NewWorld creates a world with the given gravity, in pixels per second².
+func NewWorld(gravity p5go.Vector) *World {
+	return &World{
+		Gravity:    gravity,
+		Damping:    0.99,
+		Iterations: 8,
+		TimeStep:   DefaultTimeStep,
+		MaxSteps:   defaultMaxSteps,
+		grabs:      map[int]*grab{},
+	}
+}
+
+// AddPoint adds a point at (x, y) at rest.
+func (w *World) AddPoint(x, y float64) *Point {
+	p := &Point{Position: p5go.Vector{X: x, Y: y}, Previous: p5go.Vector{X: x, Y: y}}
+	w.Points = append(w.Points, p)
+	return p
+}
+
+// Connect adds a distance constraint between a and b at their current distance.
+func (w *World) Connect(a, b *Point, stiffness float64) *Distance {
+	d := &Distance{A: a, B: b, Length: a.Position.Dist(b.Position), Stiffness: stiffness}
+	w.Constraints = append(w.Constraints, d)
+	return d
+}
+
+// ConnectAngle adds an angle constraint at b keeping the current angle between a and c.
+func (w *World) ConnectAngle(a, b, c *Point, stiffness float64) *Angle {
+	ba, bc := a.Position.Sub(b.Position), c.Position.Sub(b.Position)
+	ang := &Angle{A: a, B: b, C: c, Stiffness: stiffness}
+	ang.Angle = math.Atan2(ba.Cross(bc), ba.Dot(bc))
+	w.Constraints = append(w.Constraints, ang)
+	return ang
+}
+
+// AddCollider adds an obstacle.
+func (w *World) AddCollider(c Collider) {
+	w.Colliders = append(w.Colliders, c)
+}
+
+// Update advances the simulation by dt seconds in fixed steps. The remainder is carried over to
+// the next call.
+func (w *World) Update(dt float64) {
+	if w.TimeStep <= 0 {
+		w.TimeStep = DefaultTimeStep
+	}
+	if w.MaxSteps <= 0 {
+		w.MaxSteps = defaultMaxSteps
+	}
+	w.pending += dt
+	steps := 0
+	for w.pending >= w.TimeStep && steps < w.MaxSteps {
+		w.Step()
+		w.pending -= w.TimeStep
+		steps++
+	}
+	if steps == w.MaxSteps {
+		w.pending = 0
+	}
+}
+
+// Step advances the simulation by one time step.
+func (w *World) Step() {
+	dt2 := w.TimeStep * w.TimeStep
+	for _, p := range w.Points {
+		if p.Pinned {
+			p.Previous = p.Position
+			p.force = p5go.Vector{}
+			continue
+		}
+		acc := w.Gravity.Add(p.force.Mult(p.invMass()))
+		v := p.Velocity().Mult(w.Damping)
+		p.Previous = p.Position
+		p.Position = p.Position.Add(v).Add(acc.Mult(dt2))
+		p.force = p5go.Vector{}
+	}
+	for i := 0; i < w.Iterations; i++ {
+		w.holdGrabs()
+		for _, c := range w.Constraints {
+			c.Solve()
+		}
+		for _, p := range w.Points {
+			if p.Pinned {
+				continue
+			}
+			for _, c := range w.Colliders {
+				c.Collide(p)
+			}
+			w.keepInBounds(p)
+		}
+	}
+	w.holdGrabs()
+	w.removeBroken()
+}
+
+func (w *World) keepInBounds(p *Point) {
+	if w.Bounds.Size == (p5go.Vector{}) {
+		return
+	}
+	lo, hi := w.Bounds.Min(), w.Bounds.Max()
+	v := p.Velocity()
+	if x := clamp(p.Position.X, lo.X+p.Radius, hi.X-p.Radius); x != p.Position.X {
+		p.Position.X = x
+		p.Previous.X = x + v.X*w.Bounce
+	}
+	if y := clamp(p.Position.Y, lo.Y+p.Radius, hi.Y-p.Radius); y != p.Position.Y {
+		p.Position.Y = y
+		p.Previous.Y = y + v.Y*w.Bounce
+	}
+}
+
+func (w *World) removeBroken() {
+	kept := w.Constraints[:0]
+	for _, c := range w.Constraints {
+		if d, ok := c.(*Distance); ok && d.Broken {
+			continue
+		}
+		kept = append(kept, c)
+	}
+	for i := len(kept); i < len(w.Constraints); i++ {
+		w.Constraints[i] = nil
+	}
+	w.Constraints = kept
+}
+
+// Nearest returns the point nearest to pos within radius, or nil.
+func (w *World) Nearest(pos p5go.Vector, radius float64) *Point {
+	var best *Point
+	d := radius * radius
+	for _, p := range w.Points {
+		if di := p.Position.Sub(pos).MagSq(); di <= d {
+			best, d = p, di
+		}
+	}
+	return best
+}
+
+// Grab holds the point nearest to pos within radius for the pointer id, until Release.
+// Pinned points can be moved this way too and stay pinned where they are released.
+// It returns false if there is no point in reach.
+func (w *World) Grab(id int, pos p5go.Vector, radius float64) bool {
+	p := w.Nearest(pos, radius)
+	if p == nil {
+		return false
+	}
+	if w.grabs == nil {
+		w.grabs = map[int]*grab{}
+	}
+	w.grabs[id] = &grab{point: p, target: pos, pinned: p.Pinned}
+	return true
+}
+
+// DragTo moves the point held by the pointer id towards pos.
+func (w *World) DragTo(id int, pos p5go.Vector) {
+	if g := w.grabs[id]; g != nil {
+		g.target = pos
+	}
+}
+
+// Release lets go of the point held by the pointer id. It keeps the velocity of the drag.
+func (w *World) Release(id int) {
+	delete(w.grabs, id)
+}
+
+// holdGrabs moves the held points to their pointers.
+func (w *World) holdGrabs() {
+	for _, g := range w.grabs {
+		if g.pinned {
+			g.point.MoveTo(g.target)
+			continue
+		}
+		g.point.Position = g.target
+	}
+}
+
+// Draw draws the distance constraints as lines and the points with a radius as circles.
+func (w *World) Draw(r p5go.Renderer) {
+	for _, c := range w.Constraints {
+		if d, ok := c.(*Distance); ok && !d.Broken {
+			r.Line(d.A.Position.X, d.A.Position.Y, d.B.Position.X, d.B.Position.Y)
+		}
+	}
+	for _, p := range w.Points {
+		if p.Radius > 0 {
+			r.Circle(p.Position.X, p.Position.Y, p.Radius*2)
+		}
+	}
+}
+
+func clamp(v, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, v))
+}
@@ -0,0 +1,211 @@
+package verlet
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ryomak/p5go"
+)
+
+func TestGrabOnZeroWorld(t *testing.T) {
+	// a World built without NewWorld can still grab points
+	w := &World{TimeStep: DefaultTimeStep, Iterations: 1, MaxSteps: 1, Damping: 1}
+	p := w.AddPoint(10, 10)
+	if w.Grab(1, p5go.Vector{X: 50, Y: 50}, 5) {
+		t.Fatalf("Grab out of reach = true")
+	}
+	if !w.Grab(1, p5go.Vector{X: 12, Y: 10}, 5) {
+		t.Fatalf("Grab in reach = false")
+	}
+	w.DragTo(1, p5go.Vector{X: 30, Y: 20})
+	w.Step()
+	if p.Position != (p5go.Vector{X: 30, Y: 20}) {
+		t.Errorf("held point at %v, want (30, 20)", p.Position)
+	}
+	w.Release(1)
+	w.DragTo(1, p5go.Vector{X: 0, Y: 0})
+	w.Step()
+	if p.Position == (p5go.Vector{}) {
+		t.Errorf("released point still follows the pointer")
+	}
+}
+
+func TestRopeKeepsLength(t *testing.T) {
+	w := NewWorld(p5go.Vector{Y: 500})
+	rope := w.Rope(p5go.Vector{}, p5go.Vector{X: 100}, 10, 1)
+	rope[0].Pinned = true
+	for i := 0; i < 120; i++ {
+		w.Update(DefaultTimeStep)
+		for j := 1; j < len(rope); j++ {
+			if d := rope[j-1].Position.Dist(rope[j].Position); math.Abs(d-10) > 0.5 {
+				t.Fatalf("step %d: segment %d is %v long, want 10", i, j, d)
+			}
+		}
+	}
+	if rope[0].Position != (p5go.Vector{}) {
+		t.Errorf("pinned point moved to %v", rope[0].Position)
+	}
+	if rope[10].Position.Y < 50 {
+		t.Errorf("rope end at %v did not swing down", rope[10].Position)
+	}
+}
+
+func TestAngle(t *testing.T) {
+	w := NewWorld(p5go.Vector{})
+	a, b, c := w.AddPoint(10, 0), w.AddPoint(0, 0), w.AddPoint(0, 10)
+	b.Pinned = true
+	ang := w.ConnectAngle(a, b, c, 1)
+	if math.Abs(ang.Angle-math.Pi/2) > 1e-9 {
+		t.Fatalf("rest angle = %v, want π/2", ang.Angle)
+	}
+	c.MoveTo(p5go.Vector{X: 10, Y: 10})
+	ang.Solve()
+	ba, bc := a.Position.Sub(b.Position), c.Position.Sub(b.Position)
+	if got := math.Atan2(ba.Cross(bc), ba.Dot(bc)); math.Abs(got-math.Pi/2) > 1e-9 {
+		t.Errorf("angle after Solve = %v, want π/2", got)
+	}
+	if d := c.Position.Dist(b.Position); math.Abs(d-math.Sqrt(200)) > 1e-9 {
+		t.Errorf("Solve changed the arm length to %v", d)
+	}
+}
+
+func TestTear(t *testing.T) {
+	w := NewWorld(p5go.Vector{})
+	a, b := w.AddPoint(0, 0), w.AddPoint(10, 0)
+	a.Pinned = true
+	d := w.Connect(a, b, 1)
+	d.TearLength = 20
+	b.MoveTo(p5go.Vector{X: 15})
+	w.Step()
+	if d.Broken || len(w.Constraints) != 1 {
+		t.Fatalf("constraint broke below its tear length")
+	}
+	if got := b.Position.Dist(a.Position); math.Abs(got-10) > 1e-9 {
+		t.Errorf("stretched point at distance %v, want 10", got)
+	}
+	b.MoveTo(p5go.Vector{X: 30})
+	w.Step()
+	if !d.Broken || len(w.Constraints) != 0 {
+		t.Errorf("constraint did not break past its tear length")
+	}
+	if b.Position.X != 30 {
+		t.Errorf("torn point pulled to %v", b.Position)
+	}
+}
+
+func TestColliders(t *testing.T) {
+	tests := []struct {
+		name     string
+		collider Collider
+		// inside returns the depth of p inside the collider, or a non-positive number
+		inside func(p *Point) float64
+	}{
+		{
+			name:     "circle",
+			collider: CircleCollider(p5go.Circle{Position: p5go.Vector{X: 52, Y: 100}, Diameter: 40}),
+			inside: func(p *Point) float64 {
+				return 20 + p.Radius - p.Position.Dist(p5go.Vector{X: 52, Y: 100})
+			},
+		},
+		{
+			name:     "rect",
+			collider: RectCollider(p5go.Rectangle{Position: p5go.Vector{X: 20, Y: 100}, Size: p5go.Vector{X: 60, Y: 20}}),
+			inside: func(p *Point) float64 {
+				x, y := p.Position.X, p.Position.Y
+				return min(x-(20-p.Radius), 80+p.Radius-x, y-(100-p.Radius), 120+p.Radius-y)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewWorld(p5go.Vector{Y: 1000})
+			w.AddCollider(tt.collider)
+			var points []*Point
+			for x := 0.0; x <= 100; x += 10 {
+				p := w.AddPoint(x, 0)
+				p.Radius = 3
+				points = append(points, p)
+			}
+			for i := 0; i < 120; i++ {
+				w.Update(DefaultTimeStep)
+				for _, p := range points {
+					if d := tt.inside(p); d > 1e-9 {
+						t.Fatalf("step %d: point at %v is %v inside the collider", i, p.Position, d)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestBoundsBounce(t *testing.T) {
+	w := NewWorld(p5go.Vector{})
+	w.Damping = 1
+	w.Bounds = p5go.Rectangle{Size: p5go.Vector{X: 100, Y: 100}}
+	w.Bounce = 0.5
+	p := w.AddPoint(95, 50)
+	p.SetVelocity(p5go.Vector{X: 10})
+	w.Step()
+	if p.Position.X != 100 {
+		t.Fatalf("point at %v, want it clamped to x = 100", p.Position)
+	}
+	if v := p.Velocity(); v != (p5go.Vector{X: -5}) {
+		t.Errorf("velocity after the bounce = %v, want (-5, 0)", v)
+	}
+	w.Step()
+	if p.Position.X != 95 {
+		t.Errorf("point at %v after the bounce, want x = 95", p.Position)
+	}
+}
+
+func TestPinned(t *testing.T) {
+	w := NewWorld(p5go.Vector{Y: 1000})
+	p := w.AddPoint(10, 10)
+	p.Pinned = true
+	p.ApplyForce(p5go.Vector{X: 100})
+	w.Update(1)
+	if p.Position != (p5go.Vector{X: 10, Y: 10}) || p.Velocity() != (p5go.Vector{}) {
+		t.Errorf("pinned point moved to %v", p.Position)
+	}
+}
+
+func TestUpdateSteps(t *testing.T) {
+	// a point moving one pixel per step counts the steps
+	newWorld := func() (*World, *Point) {
+		w := NewWorld(p5go.Vector{})
+		w.Damping = 1
+		w.TimeStep = 0.25
+		p := w.AddPoint(0, 0)
+		p.SetVelocity(p5go.Vector{X: 1})
+		return w, p
+	}
+
+	w, p := newWorld()
+	w.Update(2.5 * w.TimeStep)
+	if p.Position.X != 2 {
+		t.Errorf("Update(2.5 steps) ran %v steps, want 2", p.Position.X)
+	}
+	w.Update(0.5 * w.TimeStep)
+	if p.Position.X != 3 {
+		t.Errorf("the remainder of half a step was not carried over: %v steps, want 3", p.Position.X)
+	}
+
+	w, p = newWorld()
+	w.Update(100 * w.TimeStep)
+	if p.Position.X != 5 {
+		t.Errorf("Update(100 steps) ran %v steps, want MaxSteps = 5", p.Position.X)
+	}
+	w.Update(0.5 * w.TimeStep)
+	if p.Position.X != 5 {
+		t.Errorf("the backlog of a slow frame was carried over")
+	}
+
+	// a zero World uses the default time step
+	w = &World{Damping: 1}
+	p = w.AddPoint(0, 0)
+	p.SetVelocity(p5go.Vector{X: 1})
+	w.Update(2.5 * DefaultTimeStep)
+	if p.Position.X != 2 || w.TimeStep != DefaultTimeStep || w.MaxSteps != 5 {
+		t.Errorf("zero World ran %v steps with TimeStep %v and MaxSteps %d", p.Position.X, w.TimeStep, w.MaxSteps)
+	}
+}